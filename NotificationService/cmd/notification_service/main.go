@@ -2,9 +2,18 @@ package main
 
 import (
 	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/kafka/consumer"
+	"NotificationService/internal/dataaccess/kafka/producer"
+	"NotificationService/internal/handler/consumers"
+	"NotificationService/internal/logic"
+	"NotificationService/internal/utils"
 	"NotificationService/internal/wiring"
+	"NotificationService/pkg/ticket"
+	"context"
 	"fmt"
 	"log"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -16,6 +25,19 @@ var (
 
 const (
 	flagConfigFilePath = "config-file-path"
+	flagTopic          = "topic"
+	flagFromOffset     = "from-offset"
+	flagToOffset       = "to-offset"
+	flagFromTime       = "from-time"
+	flagToTime         = "to-time"
+	flagBookingId      = "booking-id"
+	flagDryRun         = "dry-run"
+	flagBackfillId     = "backfill-id"
+	flagUserIds        = "user-ids"
+	flagSendEmail      = "send-email"
+	flagRatePerSecond  = "rate-per-second"
+	flagRestart        = "restart"
+	flagKeyId          = "key-id"
 )
 
 func standaloneServer() *cobra.Command {
@@ -33,12 +55,307 @@ func standaloneServer() *cobra.Command {
 			}
 			defer cleanup()
 
-			app.Start()
+			return app.Start()
+		},
+	}
+
+	command.Flags().String(flagConfigFilePath, "", "If provided, will use the provided config file")
+
+	return command
+}
+
+func parseTimeFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s, expected RFC 3339 time: %w", name, err)
+	}
+
+	return t, nil
+}
+
+func replay() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "replay",
+		Short: "Handle the events of a topic within an offset or time range again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			topic, err := cmd.Flags().GetString(flagTopic)
+			if err != nil {
+				return err
+			}
+
+			fromOffset, err := cmd.Flags().GetInt64(flagFromOffset)
+			if err != nil {
+				return err
+			}
+
+			toOffset, err := cmd.Flags().GetInt64(flagToOffset)
+			if err != nil {
+				return err
+			}
+
+			fromTime, err := parseTimeFlag(cmd, flagFromTime)
+			if err != nil {
+				return err
+			}
+
+			toTime, err := parseTimeFlag(cmd, flagToTime)
+			if err != nil {
+				return err
+			}
+
+			bookingId, err := cmd.Flags().GetUint32(flagBookingId)
+			if err != nil {
+				return err
+			}
+
+			dryRun, err := cmd.Flags().GetBool(flagDryRun)
+			if err != nil {
+				return err
+			}
+
+			replayer, cleanup, err := wiring.InitReplayer(configs.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			ctx, stop := utils.ContextWithSignals(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			result, err := replayer.Replay(
+				ctx,
+				topic,
+				consumer.ReplayRange{
+					FromOffset: fromOffset,
+					ToOffset:   toOffset,
+					FromTime:   fromTime,
+					ToTime:     toTime,
+				},
+				consumers.ReplayOptions{
+					BookingId: bookingId,
+					DryRun:    dryRun,
+					Output:    cmd.OutOrStdout(),
+				},
+			)
+
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"read %d, skipped %d, handled %d, failed %d events\n",
+				result.Read, result.Skipped, result.Handled, result.Failed,
+			)
+
+			return err
+		},
+	}
+
+	command.Flags().String(flagConfigFilePath, "", "If provided, will use the provided config file")
+	command.Flags().String(flagTopic, producer.TopicNameNotificationServiceNotificationCreated, "The topic to replay")
+	command.Flags().Int64(flagFromOffset, -1, "The first offset to replay in every partition, inclusive")
+	command.Flags().Int64(flagToOffset, -1, "The last offset to replay in every partition, inclusive")
+	command.Flags().String(flagFromTime, "", "Replay the events produced at or after this RFC 3339 time")
+	command.Flags().String(flagToTime, "", "Replay the events produced before this RFC 3339 time")
+	command.Flags().Uint32(flagBookingId, 0, "If provided, only replay the events of this booking")
+	command.Flags().Bool(flagDryRun, false, "Only print the events that would be replayed")
+
+	return command
+}
+
+func reconcile() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Create the missing notifications of confirmed bookings and report inconsistent ones",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			dryRun, err := cmd.Flags().GetBool(flagDryRun)
+			if err != nil {
+				return err
+			}
+
+			reconciler, cleanup, err := wiring.InitReconciler(configs.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			ctx, stop := utils.ContextWithSignals(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			report, err := reconciler.Reconcile(ctx, dryRun)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "confirmed bookings: %d, notifications: %d\n", report.ConfirmedBookingCount, report.NotificationCount)
+			fmt.Fprintf(out, "missing notifications: %d %v\n", len(report.MissingBookingIdList), report.MissingBookingIdList)
+			if !report.DryRun {
+				fmt.Fprintf(out, "failed to create: %d %v\n", len(report.CreateFailedBookingIdList), report.CreateFailedBookingIdList)
+			}
+			fmt.Fprintf(out, "orphan notifications: %d\n", len(report.OrphanNotificationList))
+			for _, notification := range report.OrphanNotificationList {
+				fmt.Fprintf(out, "  notification %d of booking %d\n", notification.ID, notification.OfBookingId)
+			}
+			fmt.Fprintf(out, "inconsistent notifications: %d\n", len(report.InconsistentNotificationList))
+			for _, inconsistentNotification := range report.InconsistentNotificationList {
+				fmt.Fprintf(
+					out,
+					"  notification %d of booking %d: %s\n",
+					inconsistentNotification.Notification.ID,
+					inconsistentNotification.Notification.OfBookingId,
+					inconsistentNotification.Reason,
+				)
+			}
+
 			return nil
 		},
 	}
 
 	command.Flags().String(flagConfigFilePath, "", "If provided, will use the provided config file")
+	command.Flags().Bool(flagDryRun, false, "Only report, without creating the missing notifications")
+
+	return command
+}
+
+func backfill() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "backfill",
+		Short: "Generate and store the invoices of historical bookings without notification",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFilePath, err := cmd.Flags().GetString(flagConfigFilePath)
+			if err != nil {
+				return err
+			}
+
+			backfillId, err := cmd.Flags().GetString(flagBackfillId)
+			if err != nil {
+				return err
+			}
+
+			userIdList, err := cmd.Flags().GetUintSlice(flagUserIds)
+			if err != nil {
+				return err
+			}
+
+			fromTime, err := parseTimeFlag(cmd, flagFromTime)
+			if err != nil {
+				return err
+			}
+
+			toTime, err := parseTimeFlag(cmd, flagToTime)
+			if err != nil {
+				return err
+			}
+
+			sendEmail, err := cmd.Flags().GetBool(flagSendEmail)
+			if err != nil {
+				return err
+			}
+
+			ratePerSecond, err := cmd.Flags().GetFloat64(flagRatePerSecond)
+			if err != nil {
+				return err
+			}
+
+			restart, err := cmd.Flags().GetBool(flagRestart)
+			if err != nil {
+				return err
+			}
+
+			backfillLogic, cleanup, err := wiring.InitBackfillLogic(configs.ConfigFilePath(configFilePath))
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			ctx, stop := utils.ContextWithSignals(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			params := logic.BackfillParams{
+				ID:            backfillId,
+				FromTime:      fromTime,
+				ToTime:        toTime,
+				SendEmail:     sendEmail,
+				RatePerSecond: ratePerSecond,
+				Restart:       restart,
+			}
+			for _, userId := range userIdList {
+				params.UserIdList = append(params.UserIdList, uint32(userId))
+			}
+
+			out := cmd.OutOrStdout()
+			progress, err := backfillLogic.Backfill(ctx, params, func(progress logic.BackfillProgress) {
+				fmt.Fprintf(
+					out,
+					"users %d/%d, processed %d, skipped %d, failed %d bookings\n",
+					progress.UserOffset, progress.UserCount,
+					progress.ProcessedBookingCount, progress.SkippedBookingCount, progress.FailedBookingCount,
+				)
+			})
+			if err != nil {
+				fmt.Fprintf(out, "backfill %s interrupted, run the same command again to resume\n", progress.ID)
+				return err
+			}
+
+			fmt.Fprintf(
+				out,
+				"backfill %s completed: processed %d, skipped %d, failed %d bookings\n",
+				progress.ID, progress.ProcessedBookingCount, progress.SkippedBookingCount, progress.FailedBookingCount,
+			)
+
+			return nil
+		},
+	}
+
+	command.Flags().String(flagConfigFilePath, "", "If provided, will use the provided config file")
+	command.Flags().String(flagBackfillId, "", "The checkpoint name, derived from the other flags if not provided")
+	command.Flags().UintSlice(flagUserIds, nil, "If provided, only backfill the bookings of these users")
+	command.Flags().String(flagFromTime, "", "Backfill the bookings made at or after this RFC 3339 time")
+	command.Flags().String(flagToTime, "", "Backfill the bookings made before this RFC 3339 time")
+	command.Flags().Bool(flagSendEmail, false, "Also email the generated invoices")
+	command.Flags().Float64(flagRatePerSecond, 5, "The maximum number of invoices generated per second")
+	command.Flags().Bool(flagRestart, false, "Start over, ignoring the checkpoint of a previous run")
+
+	return command
+}
+
+func generateTicketSigningKey() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "generate-ticket-signing-key",
+		Short: "Generate a key pair to sign ticket QR codes with, printed as a ticket signing_keys entry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyId, err := cmd.Flags().GetString(flagKeyId)
+			if err != nil {
+				return err
+			}
+			if keyId == "" {
+				keyId = time.Now().UTC().Format("2006-01-02")
+			}
+
+			privateKey, publicKey, err := ticket.GenerateKey()
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("- id: %q\n  private_key: %q\n  public_key: %q\n", keyId, privateKey, publicKey)
+			return nil
+		},
+	}
+
+	command.Flags().String(flagKeyId, "", "The id of the key, defaults to the current date")
 
 	return command
 }
@@ -50,6 +367,10 @@ func main() {
 
 	rootCommand.AddCommand(
 		standaloneServer(),
+		replay(),
+		reconcile(),
+		backfill(),
+		generateTicketSigningKey(),
 	)
 
 	if err := rootCommand.Execute(); err != nil {
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDrainTimeout = 30 * time.Second
+)
+
+// Component is a long running part of the service. Start blocks until its context is cancelled or the
+// component fails, Stop releases what is left once Start has returned. Either of them may be nil.
+type Component struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// Lifecycle starts components in the order they were appended and, once a termination signal is received
+// or one of them fails, stops them in reverse order within the drain timeout.
+type Lifecycle struct {
+	components   []Component
+	drainTimeout time.Duration
+	logger       *zap.Logger
+}
+
+func NewLifecycle(
+	lifecycleConfig configs.Lifecycle,
+	logger *zap.Logger,
+) *Lifecycle {
+	drainTimeout := lifecycleConfig.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
+
+	return &Lifecycle{
+		drainTimeout: drainTimeout,
+		logger:       logger,
+	}
+}
+
+func (l *Lifecycle) Append(component Component) {
+	l.components = append(l.components, component)
+}
+
+type runningComponent struct {
+	Component
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (l *Lifecycle) Run(ctx context.Context) error {
+	rootCtx, stopSignals := utils.ContextWithSignals(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	failed := make(chan error, len(l.components))
+	runningComponents := make([]runningComponent, 0, len(l.components))
+	for _, component := range l.components {
+		componentCtx, cancel := context.WithCancel(context.Background())
+		running := runningComponent{
+			Component: component,
+			cancel:    cancel,
+			done:      make(chan struct{}),
+		}
+		runningComponents = append(runningComponents, running)
+
+		go func() {
+			defer close(running.done)
+			if running.Start == nil {
+				<-componentCtx.Done()
+				return
+			}
+
+			logger := l.logger.With(zap.String("component", running.Name))
+			if err := running.Start(componentCtx); err != nil && componentCtx.Err() == nil {
+				logger.With(zap.Error(err)).Error("component stopped unexpectedly")
+				failed <- err
+				return
+			}
+			logger.Info("component stopped")
+		}()
+	}
+
+	var runErr error
+	select {
+	case <-rootCtx.Done():
+		l.logger.Info("received termination signal, shutting down")
+	case runErr = <-failed:
+		l.logger.Info("a component failed, shutting down")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), l.drainTimeout)
+	defer cancel()
+
+	for i := len(runningComponents) - 1; i >= 0; i-- {
+		if err := l.stop(shutdownCtx, runningComponents[i]); err != nil {
+			runErr = errors.Join(runErr, err)
+		}
+	}
+
+	return runErr
+}
+
+func (l *Lifecycle) stop(ctx context.Context, component runningComponent) error {
+	logger := l.logger.With(zap.String("component", component.Name))
+	logger.Info("stopping component")
+
+	component.cancel()
+	if component.Stop != nil {
+		if err := component.Stop(ctx); err != nil {
+			logger.With(zap.Error(err)).Error("failed to stop component")
+			return err
+		}
+	}
+
+	select {
+	case <-component.done:
+		return nil
+	case <-ctx.Done():
+		logger.Warn("drain timeout exceeded before component stopped")
+		return ctx.Err()
+	}
+}
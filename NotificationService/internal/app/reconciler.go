@@ -0,0 +1,38 @@
+package app
+
+import (
+	"NotificationService/internal/dataaccess/kafka/producer"
+	"NotificationService/internal/logic"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type Reconciler struct {
+	reconciliationLogic logic.ReconciliationLogic
+	kafkaProducer       producer.Producer
+	logger              *zap.Logger
+}
+
+func NewReconciler(
+	reconciliationLogic logic.ReconciliationLogic,
+	kafkaProducer producer.Producer,
+	logger *zap.Logger,
+) Reconciler {
+	return Reconciler{
+		reconciliationLogic: reconciliationLogic,
+		kafkaProducer:       kafkaProducer,
+		logger:              logger,
+	}
+}
+
+// Reconcile runs the reconciliation once, then flushes the notification created events it produced.
+func (r Reconciler) Reconcile(ctx context.Context, dryRun bool) (logic.ReconciliationReport, error) {
+	report, err := r.reconciliationLogic.Reconcile(ctx, dryRun)
+
+	if closeErr := r.kafkaProducer.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	return report, err
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"NotificationService/internal/dataaccess/kafka/consumer"
+	"NotificationService/internal/dataaccess/kafka/producer"
+	"NotificationService/internal/handler/consumers"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type Replayer struct {
+	eventReplayer consumers.NotificationServiceEventReplayer
+	kafkaProducer producer.Producer
+	logger        *zap.Logger
+}
+
+func NewReplayer(
+	eventReplayer consumers.NotificationServiceEventReplayer,
+	kafkaProducer producer.Producer,
+	logger *zap.Logger,
+) Replayer {
+	return Replayer{
+		eventReplayer: eventReplayer,
+		kafkaProducer: kafkaProducer,
+		logger:        logger,
+	}
+}
+
+// Replay handles the events of queueName within replayRange again, then flushes the events produced
+// while handling them.
+func (r Replayer) Replay(
+	ctx context.Context,
+	queueName string,
+	replayRange consumer.ReplayRange,
+	options consumers.ReplayOptions,
+) (consumers.ReplayResult, error) {
+	result, err := r.eventReplayer.Replay(ctx, queueName, replayRange, options)
+
+	if closeErr := r.kafkaProducer.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	return result, err
+}
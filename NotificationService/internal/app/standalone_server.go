@@ -1,43 +1,83 @@
 package app
 
 import (
+	"NotificationService/internal/dataaccess/kafka/producer"
 	"NotificationService/internal/handler/consumers"
 	"NotificationService/internal/handler/grpc"
-	"NotificationService/internal/utils"
+	"NotificationService/internal/handler/http"
+	"NotificationService/internal/handler/jobs"
 	"context"
-	"syscall"
 
 	"go.uber.org/zap"
 )
 
 type StandaloneServer struct {
 	grpcServer                       grpc.Server
+	httpServer                       http.Server
 	notificationServiceKafkaConsumer consumers.NotificationServiceKafkaConsumer
+	kafkaProducer                    producer.Producer
+	reconciliationJob                jobs.ReconciliationJob
+	notificationSchedulerJob         jobs.NotificationSchedulerJob
+	lifecycle                        *Lifecycle
 	logger                           *zap.Logger
 }
 
 func NewStandAloneServer(
 	grpcServer grpc.Server,
+	httpServer http.Server,
 	notificationServiceKafkaConsumer consumers.NotificationServiceKafkaConsumer,
+	kafkaProducer producer.Producer,
+	reconciliationJob jobs.ReconciliationJob,
+	notificationSchedulerJob jobs.NotificationSchedulerJob,
+	lifecycle *Lifecycle,
 	logger *zap.Logger,
 ) (StandaloneServer, error) {
 	return StandaloneServer{
 		grpcServer:                       grpcServer,
+		httpServer:                       httpServer,
 		notificationServiceKafkaConsumer: notificationServiceKafkaConsumer,
+		kafkaProducer:                    kafkaProducer,
+		reconciliationJob:                reconciliationJob,
+		notificationSchedulerJob:         notificationSchedulerJob,
+		lifecycle:                        lifecycle,
 		logger:                           logger,
 	}, nil
 }
 
-func (s *StandaloneServer) Start() {
-	go func() {
-		err := s.grpcServer.Start(context.Background())
-		s.logger.With(zap.Error(err)).Error("gRPC server stopped")
-	}()
+// Start runs the server until a termination signal is received. Components are stopped in reverse order:
+// the Kafka consumer stops consuming and drains in-flight notifications, then the producer is flushed and
+// finally the gRPC server and the HTTP server serving metrics stop gracefully.
+func (s *StandaloneServer) Start() error {
+	s.lifecycle.Append(Component{
+		Name:  "http_server",
+		Start: s.httpServer.Start,
+		Stop:  s.httpServer.Stop,
+	})
+	s.lifecycle.Append(Component{
+		Name:  "grpc_server",
+		Start: s.grpcServer.Start,
+		Stop:  s.grpcServer.Stop,
+	})
+	s.lifecycle.Append(Component{
+		Name: "kafka_producer",
+		Stop: func(ctx context.Context) error {
+			return s.kafkaProducer.Close()
+		},
+	})
+	s.lifecycle.Append(Component{
+		Name:  "notification_kafka_consumer",
+		Start: s.notificationServiceKafkaConsumer.Start,
+	})
+	s.lifecycle.Append(Component{
+		Name:  "notification_scheduler_job",
+		Start: s.notificationSchedulerJob.Start,
+	})
+	if s.reconciliationJob.Enabled() {
+		s.lifecycle.Append(Component{
+			Name:  "reconciliation_job",
+			Start: s.reconciliationJob.Start,
+		})
+	}
 
-	go func() {
-		err := s.notificationServiceKafkaConsumer.Start(context.Background())
-		s.logger.With(zap.Error(err)).Info("notification kafka consumer stopped")
-	}()
-
-	utils.WaitForSignals(syscall.SIGINT, syscall.SIGTERM)
+	return s.lifecycle.Run(context.Background())
 }
@@ -1,5 +1,6 @@
 package configs
 
 type BookingServiceClient struct {
-	Addresses []string `yaml:"addresses"`
+	Addresses        []string `yaml:"addresses"`
+	GRPCClientPolicy `yaml:",inline"`
 }
@@ -0,0 +1,25 @@
+package configs
+
+import "time"
+
+type BrandingProfile struct {
+	Name string `yaml:"name"`
+	// TheaterIds are the theaters of the chain the profile brands.
+	TheaterIds []uint32 `yaml:"theater_ids"`
+	// Layout is the name of the PDF layout, the default layout if empty.
+	Layout string `yaml:"layout"`
+	// LogoFilename is the S3 key of a PNG or JPEG logo, the invoice has no logo if empty.
+	LogoFilename string `yaml:"logo_filename"`
+	// PrimaryColor is written as #RRGGBB.
+	PrimaryColor string `yaml:"primary_color"`
+	LegalFooter  string `yaml:"legal_footer"`
+}
+
+type Branding struct {
+	// Default brands the theaters without a profile of their own.
+	Default BrandingProfile `yaml:"default"`
+	// Profiles are matched in order, the first profile listing the theater of a booking is used.
+	Profiles []BrandingProfile `yaml:"profiles"`
+	// LogoCacheTTL is how long logos are cached after being read from S3, it defaults to 1h.
+	LogoCacheTTL time.Duration `yaml:"logo_cache_ttl"`
+}
@@ -0,0 +1,22 @@
+package configs
+
+import "time"
+
+type CacheType string
+
+const (
+	CacheTypeInMemory CacheType = "in_memory"
+	CacheTypeRedis    CacheType = "redis"
+)
+
+type Cache struct {
+	Type     CacheType `yaml:"type"`
+	Size     int       `yaml:"size"`
+	Address  string    `yaml:"address"`
+	Username string    `yaml:"username"`
+	Password string    `yaml:"password"`
+	Database int       `yaml:"database"`
+	// LoadTimeout bounds loading a value missing from the cache, which outlives the request that started it
+	// since concurrent requests for the same key wait for it too. It defaults to 10 seconds.
+	LoadTimeout time.Duration `yaml:"load_timeout"`
+}
@@ -0,0 +1,13 @@
+package configs
+
+import "time"
+
+type CheckIn struct {
+	// OpensBeforeShowtimeStart and ClosesAfterShowtimeStart bound the window around the start of a showtime
+	// during which its tickets can be checked in.
+	OpensBeforeShowtimeStart time.Duration `yaml:"opens_before_showtime_start"`
+	ClosesAfterShowtimeStart time.Duration `yaml:"closes_after_showtime_start"`
+	// StaffPermission is the UserService permission a caller must hold to check tickets in and to read
+	// check-in reports.
+	StaffPermission string `yaml:"staff_permission"`
+}
@@ -11,15 +11,29 @@ import (
 type ConfigFilePath string
 
 type Config struct {
-	GRPC                 GRPC                 `yaml:"grpc"`
-	Database             Database             `yaml:"database"`
-	Log                  Log                  `yaml:"log"`
-	S3                   S3                   `yaml:"s3"`
-	Kafka                Kafka                `yaml:"kafka"`
-	UserServiceClient    UserServiceClient    `yaml:"user_service_client"`
-	MovieServiceClient   MovieServiceClient   `yaml:"movie_service_client"`
-	BookingServiceClient BookingServiceClient `yaml:"booking_service_client"`
-	Mail                 Mail                 `yaml:"mail"`
+	GRPC                  GRPC                  `yaml:"grpc"`
+	Database              Database              `yaml:"database"`
+	Log                   Log                   `yaml:"log"`
+	S3                    S3                    `yaml:"s3"`
+	Kafka                 Kafka                 `yaml:"kafka"`
+	UserServiceClient     UserServiceClient     `yaml:"user_service_client"`
+	MovieServiceClient    MovieServiceClient    `yaml:"movie_service_client"`
+	BookingServiceClient  BookingServiceClient  `yaml:"booking_service_client"`
+	Mail                  Mail                  `yaml:"mail"`
+	NotificationScheduler NotificationScheduler `yaml:"notification_scheduler"`
+	Cache                 Cache                 `yaml:"cache"`
+	Lifecycle             Lifecycle             `yaml:"lifecycle"`
+	Reconciliation        Reconciliation        `yaml:"reconciliation"`
+	HTTP                  HTTP                  `yaml:"http"`
+	Tracing               Tracing               `yaml:"tracing"`
+	Health                Health                `yaml:"health"`
+	PDFGenerator          PDFGenerator          `yaml:"pdf_generator"`
+	Ticket                Ticket                `yaml:"ticket"`
+	CheckIn               CheckIn               `yaml:"check_in"`
+	Branding              Branding              `yaml:"branding"`
+	Invoice               Invoice               `yaml:"invoice"`
+	Theater               Theater               `yaml:"theater"`
+	NotificationGroup     NotificationGroup     `yaml:"notification_group"`
 }
 
 func NewConfig(configFilePath ConfigFilePath) (Config, error) {
@@ -2,4 +2,7 @@ package configs
 
 type GRPC struct {
 	Address string `yaml:"address"`
+	// SupportPermission is the UserService permission a caller must hold to read the delivery attempts of a
+	// booking, it defaults to notifications.support.
+	SupportPermission string `yaml:"support_permission"`
 }
@@ -0,0 +1,22 @@
+package configs
+
+import "time"
+
+type GRPCClientRetry struct {
+	MaxAttempts    uint          `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	JitterFraction float64       `yaml:"jitter_fraction"`
+}
+
+type GRPCClientCircuitBreaker struct {
+	ConsecutiveFailures uint32        `yaml:"consecutive_failures"`
+	HalfOpenMaxRequests uint32        `yaml:"half_open_max_requests"`
+	Interval            time.Duration `yaml:"interval"`
+	OpenTimeout         time.Duration `yaml:"open_timeout"`
+}
+
+type GRPCClientPolicy struct {
+	Timeout        time.Duration            `yaml:"timeout"`
+	Retry          GRPCClientRetry          `yaml:"retry"`
+	CircuitBreaker GRPCClientCircuitBreaker `yaml:"circuit_breaker"`
+}
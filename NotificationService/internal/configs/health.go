@@ -0,0 +1,14 @@
+package configs
+
+import "time"
+
+type Health struct {
+	// CheckTimeout bounds each dependency check of the readiness probe.
+	CheckTimeout time.Duration `yaml:"check_timeout"`
+	// CacheTTL is how long the result of a dependency check is reused, so that frequent probes do not load
+	// the dependencies.
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	// ConsumerStallThreshold is how long the Kafka consumer may have messages to handle without finishing any
+	// before the liveness probe fails, it defaults to 5 minutes.
+	ConsumerStallThreshold time.Duration `yaml:"consumer_stall_threshold"`
+}
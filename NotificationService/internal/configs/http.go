@@ -0,0 +1,6 @@
+package configs
+
+type HTTP struct {
+	// Address serves the operational endpoints: /metrics, /healthz and /readyz.
+	Address string `yaml:"address"`
+}
@@ -0,0 +1,28 @@
+package configs
+
+type InvoiceIssuer struct {
+	// ID names the numbering sequence of the issuer, it must not change once invoices have been issued.
+	ID string `yaml:"id"`
+	// Series is printed before the year and number of the invoices, it defaults to ID.
+	Series  string `yaml:"series"`
+	Name    string `yaml:"name"`
+	TaxID   string `yaml:"tax_id"`
+	Address string `yaml:"address"`
+	// TheaterIds are the theaters the issuer sells the tickets of.
+	TheaterIds []uint32 `yaml:"theater_ids"`
+}
+
+type Invoice struct {
+	// DefaultIssuer issues the invoices of the theaters without an issuer of their own.
+	DefaultIssuer InvoiceIssuer `yaml:"default_issuer"`
+	// Issuers are matched in order, the first issuer listing the theater of a booking is used.
+	Issuers []InvoiceIssuer `yaml:"issuers"`
+	// TaxName and TaxRateBasisPoints describe the tax line of the invoices, 1000 basis points being 10%.
+	// They default to a 10% VAT when TaxName is empty.
+	TaxName            string `yaml:"tax_name"`
+	TaxRateBasisPoints uint32 `yaml:"tax_rate_basis_points"`
+	// PricesExcludeTax adds the tax to the booking amount, by default the booking amount includes the tax.
+	PricesExcludeTax bool `yaml:"prices_exclude_tax"`
+	// TimeZone decides the year invoices are numbered in, it defaults to Asia/Ho_Chi_Minh.
+	TimeZone string `yaml:"time_zone"`
+}
@@ -1,6 +1,66 @@
 package configs
 
+import "time"
+
+type KafkaSASLMechanism string
+
+const (
+	KafkaSASLMechanismPlain       KafkaSASLMechanism = "PLAIN"
+	KafkaSASLMechanismSCRAMSHA256 KafkaSASLMechanism = "SCRAM-SHA-256"
+	KafkaSASLMechanismSCRAMSHA512 KafkaSASLMechanism = "SCRAM-SHA-512"
+)
+
+type KafkaSASL struct {
+	Enabled   bool               `yaml:"enabled"`
+	Mechanism KafkaSASLMechanism `yaml:"mechanism"`
+	Username  string             `yaml:"username"`
+	Password  string             `yaml:"password"`
+}
+
+type KafkaTLS struct {
+	Enabled bool `yaml:"enabled"`
+	// CAFile, CertFile and KeyFile are paths to PEM encoded files, the client certificate is optional.
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	ServerName         string `yaml:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+type KafkaConsumer struct {
+	// GroupID defaults to the client ID of the Kafka config.
+	GroupID string `yaml:"group_id"`
+	// InitialOffset is where a group without committed offsets starts consuming, either oldest or newest.
+	InitialOffset     string         `yaml:"initial_offset"`
+	SessionTimeout    time.Duration  `yaml:"session_timeout"`
+	HeartbeatInterval time.Duration  `yaml:"heartbeat_interval"`
+	Concurrency       int            `yaml:"concurrency"`
+	TopicConcurrency  map[string]int `yaml:"topic_concurrency"`
+	// RetryMax is how many times a message whose handling failed is retried before it is parked on the dead
+	// letter topic, it defaults to 3. RetryBackoff is the delay before the first retry, doubled on each
+	// retry, it defaults to 1s.
+	RetryMax     int           `yaml:"retry_max"`
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+}
+
+type KafkaProducer struct {
+	Async          bool          `yaml:"async"`
+	Idempotent     bool          `yaml:"idempotent"`
+	Compression    string        `yaml:"compression"`
+	RetryMax       int           `yaml:"retry_max"`
+	FlushFrequency time.Duration `yaml:"flush_frequency"`
+	FlushMessages  int           `yaml:"flush_messages"`
+	FlushBytes     int           `yaml:"flush_bytes"`
+	// EventEncoding is the encoding of the event envelopes, either json or protobuf.
+	EventEncoding string `yaml:"event_encoding"`
+}
+
 type Kafka struct {
-	Addresses []string `yaml:"addresses"`
-	ClientID  string   `yaml:"client_id"`
+	Addresses []string      `yaml:"addresses"`
+	ClientID  string        `yaml:"client_id"`
+	Version   string        `yaml:"version"`
+	SASL      KafkaSASL     `yaml:"sasl"`
+	TLS       KafkaTLS      `yaml:"tls"`
+	Consumer  KafkaConsumer `yaml:"consumer"`
+	Producer  KafkaProducer `yaml:"producer"`
 }
@@ -0,0 +1,7 @@
+package configs
+
+import "time"
+
+type Lifecycle struct {
+	DrainTimeout time.Duration `yaml:"drain_timeout"`
+}
@@ -0,0 +1,12 @@
+package configs
+
+import "time"
+
+type NotificationGroup struct {
+	// Disabled sends a notification per booking, instead of grouping the bookings of a user for the same
+	// showtime.
+	Disabled bool `yaml:"disabled"`
+	// Window is how long a group stays open for the next bookings of its user and showtime after its first
+	// booking, it defaults to 1 minute. The email of the group is sent once it closes.
+	Window time.Duration `yaml:"window"`
+}
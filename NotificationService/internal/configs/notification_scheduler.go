@@ -0,0 +1,13 @@
+package configs
+
+import "time"
+
+type NotificationScheduler struct {
+	// Interval is how often the notifications that are due are enqueued, it defaults to 5 seconds.
+	Interval time.Duration `yaml:"interval"`
+	// BatchSize is the maximum number of notifications enqueued per interval, it defaults to 100.
+	BatchSize int `yaml:"batch_size"`
+	// RetryDelay is how long a notification deferred because a downstream service is unavailable waits
+	// before it is enqueued again, it defaults to 30 seconds.
+	RetryDelay time.Duration `yaml:"retry_delay"`
+}
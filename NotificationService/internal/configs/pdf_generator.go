@@ -0,0 +1,23 @@
+package configs
+
+type PDFFontFamily struct {
+	Name string `yaml:"name"`
+	// Regular, Bold and Italic are paths of TrueType font files, Bold and Italic default to Regular.
+	Regular string `yaml:"regular"`
+	Bold    string `yaml:"bold"`
+	Italic  string `yaml:"italic"`
+}
+
+type PDFGenerator struct {
+	// Concurrency is the maximum number of PDFs and ticket cards rendered at the same time, it defaults to the
+	// number of CPUs.
+	Concurrency int `yaml:"concurrency"`
+	// Fonts are tried in order for each text before the embedded DejaVu Sans, the first one with glyphs for
+	// every character of the text is used.
+	Fonts []PDFFontFamily `yaml:"fonts"`
+	// Locale of the dates in the PDFs, "vi" or "en", it defaults to "vi".
+	Locale string `yaml:"locale"`
+	// LayoutDirectory holds YAML or JSON layouts, in addition to the embedded ones. A layout replaces the
+	// embedded layout with the same name.
+	LayoutDirectory string `yaml:"layout_directory"`
+}
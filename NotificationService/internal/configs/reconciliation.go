@@ -0,0 +1,11 @@
+package configs
+
+import "time"
+
+type Reconciliation struct {
+	// Enabled runs the reconciliation periodically in the standalone server, the reconcile command works
+	// regardless.
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+	PageSize uint32        `yaml:"page_size"`
+}
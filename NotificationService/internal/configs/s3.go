@@ -5,4 +5,6 @@ type S3 struct {
 	Bucket   string `yaml:"bucket"`
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
+	// PosterBucket is the bucket MovieService stores movie posters in, it defaults to posters.
+	PosterBucket string `yaml:"poster_bucket"`
 }
@@ -0,0 +1,15 @@
+package configs
+
+type TheaterTimeZone struct {
+	TheaterIds []uint32 `yaml:"theater_ids"`
+	// TimeZone is an IANA time zone name, e.g. Asia/Ho_Chi_Minh.
+	TimeZone string `yaml:"time_zone"`
+}
+
+type Theater struct {
+	// DefaultTimeZone is the time zone of the theaters not listed in TimeZones, it defaults to
+	// Asia/Ho_Chi_Minh.
+	DefaultTimeZone string `yaml:"default_time_zone"`
+	// TimeZones are matched in order, the first entry listing a theater gives its time zone.
+	TimeZones []TheaterTimeZone `yaml:"time_zones"`
+}
@@ -0,0 +1,19 @@
+package configs
+
+import "time"
+
+type TicketSigningKey struct {
+	ID string `yaml:"id"`
+	// PrivateKey is the base64 encoded seed of an Ed25519 key. A retired key only keeps its PublicKey, so
+	// that the tickets it signed can still be verified.
+	PrivateKey string `yaml:"private_key"`
+	PublicKey  string `yaml:"public_key"`
+}
+
+type Ticket struct {
+	// SigningKeyID is the id of the key in SigningKeys that new tickets are signed with.
+	SigningKeyID string             `yaml:"signing_key_id"`
+	SigningKeys  []TicketSigningKey `yaml:"signing_keys"`
+	// ExpiryAfterShowtimeEnd is how long a ticket stays valid after the end of its showtime.
+	ExpiryAfterShowtimeEnd time.Duration `yaml:"expiry_after_showtime_end"`
+}
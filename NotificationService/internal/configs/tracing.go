@@ -0,0 +1,13 @@
+package configs
+
+type Tracing struct {
+	// Exporter is either otlp or stdout, tracing is disabled if empty. Trace context is propagated either way.
+	Exporter string `yaml:"exporter"`
+	// OTLPEndpoint is the host:port of the collector receiving spans over OTLP/gRPC.
+	OTLPEndpoint string `yaml:"otlp_endpoint"`
+	OTLPInsecure bool   `yaml:"otlp_insecure"`
+	// SampleRatio is the fraction of the traces started by this service that are sampled, 1 if unset. Traces
+	// started upstream follow the sampling decision of their parent.
+	SampleRatio float64 `yaml:"sample_ratio"`
+	ServiceName string  `yaml:"service_name"`
+}
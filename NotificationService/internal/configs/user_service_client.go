@@ -1,5 +1,9 @@
 package configs
 
+import "time"
+
 type UserServiceClient struct {
-	Addresses []string `yaml:"addresses"`
+	Addresses        []string      `yaml:"addresses"`
+	CacheTTL         time.Duration `yaml:"cache_ttl"`
+	GRPCClientPolicy `yaml:",inline"`
 }
@@ -5,6 +5,7 @@ import "github.com/google/wire"
 var WireSet = wire.NewSet(
 	NewConfig,
 	wire.FieldsOf(new(Config), "GRPC"),
+	wire.FieldsOf(new(Config), "HTTP"),
 	wire.FieldsOf(new(Config), "Database"),
 	wire.FieldsOf(new(Config), "Log"),
 	wire.FieldsOf(new(Config), "S3"),
@@ -13,4 +14,17 @@ var WireSet = wire.NewSet(
 	wire.FieldsOf(new(Config), "MovieServiceClient"),
 	wire.FieldsOf(new(Config), "BookingServiceClient"),
 	wire.FieldsOf(new(Config), "Mail"),
+	wire.FieldsOf(new(Config), "NotificationScheduler"),
+	wire.FieldsOf(new(Config), "Cache"),
+	wire.FieldsOf(new(Config), "Lifecycle"),
+	wire.FieldsOf(new(Config), "Reconciliation"),
+	wire.FieldsOf(new(Config), "Tracing"),
+	wire.FieldsOf(new(Config), "Health"),
+	wire.FieldsOf(new(Config), "PDFGenerator"),
+	wire.FieldsOf(new(Config), "Ticket"),
+	wire.FieldsOf(new(Config), "CheckIn"),
+	wire.FieldsOf(new(Config), "Branding"),
+	wire.FieldsOf(new(Config), "Invoice"),
+	wire.FieldsOf(new(Config), "Theater"),
+	wire.FieldsOf(new(Config), "NotificationGroup"),
 )
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/metrics"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultLoadTimeout = 10 * time.Second
+)
+
+var (
+	ErrCacheMiss = errors.New("cache miss")
+)
+
+type LoadFunc func(ctx context.Context) ([]byte, error)
+
+// Client caches values fetched from other services. Concurrent loads of the same key are collapsed into
+// a single call of the LoadFunc, which is not cancelled together with the caller that started it, since
+// the other callers wait for it too. It is bounded by the load timeout instead.
+type Client interface {
+	GetOrLoad(ctx context.Context, namespace string, key string, ttl time.Duration, load LoadFunc) ([]byte, error)
+}
+
+// store is the backing key-value storage of the cache. Get returns ErrCacheMiss when the key is absent or expired.
+type store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Close() error
+}
+
+type client struct {
+	store       store
+	group       singleflight.Group
+	loadTimeout time.Duration
+	logger      *zap.Logger
+}
+
+func NewClient(
+	cacheConfig configs.Cache,
+	logger *zap.Logger,
+) (Client, func(), error) {
+	logger = logger.Named("Cache")
+
+	var (
+		cacheStore store
+		err        error
+	)
+	switch cacheConfig.Type {
+	case configs.CacheTypeInMemory, "":
+		cacheStore, err = newInMemoryStore(cacheConfig)
+	case configs.CacheTypeRedis:
+		cacheStore, err = newRedisStore(cacheConfig)
+	default:
+		err = fmt.Errorf("unsupported cache type: %s", cacheConfig.Type)
+	}
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create cache store")
+		return nil, nil, err
+	}
+
+	loadTimeout := cacheConfig.LoadTimeout
+	if loadTimeout <= 0 {
+		loadTimeout = defaultLoadTimeout
+	}
+
+	c := &client{
+		store:       cacheStore,
+		loadTimeout: loadTimeout,
+		logger:      logger,
+	}
+
+	cleanup := func() {
+		if err := cacheStore.Close(); err != nil {
+			logger.With(zap.Error(err)).Error("failed to close cache store")
+		}
+	}
+
+	return c, cleanup, nil
+}
+
+func (c *client) GetOrLoad(
+	ctx context.Context,
+	namespace string,
+	key string,
+	ttl time.Duration,
+	load LoadFunc,
+) ([]byte, error) {
+	logger := c.logger.With(zap.String("namespace", namespace)).With(zap.String("key", key))
+	storeKey := fmt.Sprintf("%s:%s", namespace, key)
+
+	value, err := c.store.Get(ctx, storeKey)
+	if err == nil {
+		metrics.CacheLookups.WithLabelValues(namespace, metrics.CacheResultHit).Inc()
+		return value, nil
+	}
+	if !errors.Is(err, ErrCacheMiss) {
+		// The cache is only an optimization, fall back to loading the value when the store is unavailable.
+		metrics.CacheStoreErrors.WithLabelValues(namespace, metrics.CacheOperationGet).Inc()
+		logger.With(zap.Error(err)).Warn("failed to get value from cache")
+	}
+	metrics.CacheLookups.WithLabelValues(namespace, metrics.CacheResultMiss).Inc()
+
+	result, err, _ := c.group.Do(storeKey, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.loadTimeout)
+		defer cancel()
+
+		value, err := load(loadCtx)
+		metrics.CacheLoads.WithLabelValues(namespace, metrics.Result(err)).Inc()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.store.Set(loadCtx, storeKey, value, ttl); err != nil {
+			metrics.CacheStoreErrors.WithLabelValues(namespace, metrics.CacheOperationSet).Inc()
+			logger.With(zap.Error(err)).Warn("failed to set value to cache")
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]byte), nil
+}
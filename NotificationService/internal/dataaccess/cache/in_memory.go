@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"NotificationService/internal/configs"
+	"context"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	defaultInMemoryCacheSize = 10000
+)
+
+type inMemoryEntry struct {
+	value    []byte
+	expireAt time.Time
+}
+
+type inMemoryStore struct {
+	entries *lru.Cache[string, inMemoryEntry]
+}
+
+func newInMemoryStore(cacheConfig configs.Cache) (store, error) {
+	size := cacheConfig.Size
+	if size <= 0 {
+		size = defaultInMemoryCacheSize
+	}
+
+	entries, err := lru.New[string, inMemoryEntry](size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inMemoryStore{
+		entries: entries,
+	}, nil
+}
+
+func (s *inMemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	entry, ok := s.entries.Get(key)
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	if time.Now().After(entry.expireAt) {
+		s.entries.Remove(key)
+		return nil, ErrCacheMiss
+	}
+
+	return entry.value, nil
+}
+
+func (s *inMemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.entries.Add(key, inMemoryEntry{
+		value:    value,
+		expireAt: time.Now().Add(ttl),
+	})
+	return nil
+}
+
+func (s *inMemoryStore) Close() error {
+	s.entries.Purge()
+	return nil
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"NotificationService/internal/configs"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisStore struct {
+	redisClient *redis.Client
+}
+
+func newRedisStore(cacheConfig configs.Cache) (store, error) {
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cacheConfig.Address,
+		Username: cacheConfig.Username,
+		Password: cacheConfig.Password,
+		DB:       cacheConfig.Database,
+	})
+
+	return &redisStore{
+		redisClient: redisClient,
+	}, nil
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.redisClient.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.redisClient.Set(ctx, key, value, ttl).Err()
+}
+
+func (s *redisStore) Close() error {
+	return s.redisClient.Close()
+}
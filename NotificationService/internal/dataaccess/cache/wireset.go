@@ -0,0 +1,7 @@
+package cache
+
+import "github.com/google/wire"
+
+var WireSet = wire.NewSet(
+	NewClient,
+)
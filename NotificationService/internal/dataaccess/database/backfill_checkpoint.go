@@ -0,0 +1,72 @@
+package database
+
+import (
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type BackfillCheckpoint struct {
+	ID                    string    `gorm:"column:backfill_id;primaryKey"`
+	NextUserOffset        uint32    `gorm:"column:next_user_offset"`
+	ProcessedBookingCount uint32    `gorm:"column:processed_booking_count"`
+	SkippedBookingCount   uint32    `gorm:"column:skipped_booking_count"`
+	FailedBookingCount    uint32    `gorm:"column:failed_booking_count"`
+	Completed             bool      `gorm:"column:completed"`
+	UpdatedAt             time.Time `gorm:"column:updated_at"`
+}
+
+func (BackfillCheckpoint) TableName() string {
+	return "notification_service_backfill_checkpoint_tab"
+}
+
+type BackfillCheckpointDataAccessor interface {
+	// GetBackfillCheckpoint returns a new checkpoint if the backfill has never been run.
+	GetBackfillCheckpoint(ctx context.Context, id string) (*BackfillCheckpoint, error)
+	SaveBackfillCheckpoint(ctx context.Context, checkpoint *BackfillCheckpoint) error
+}
+
+type backfillCheckpointDataAccessor struct {
+	database Database
+	logger   *zap.Logger
+}
+
+func NewBackfillCheckpointDataAccessor(database Database, logger *zap.Logger) BackfillCheckpointDataAccessor {
+	return &backfillCheckpointDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+func (b backfillCheckpointDataAccessor) GetBackfillCheckpoint(ctx context.Context, id string) (*BackfillCheckpoint, error) {
+	logger := utils.LoggerWithContext(ctx, b.logger).With(zap.String("backfill_id", id))
+
+	var checkpoint BackfillCheckpoint
+	result := b.database.WithContext(ctx).First(&checkpoint, "backfill_id = ?", id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return &BackfillCheckpoint{ID: id}, nil
+	}
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get backfill checkpoint")
+		return nil, result.Error
+	}
+
+	return &checkpoint, nil
+}
+
+func (b backfillCheckpointDataAccessor) SaveBackfillCheckpoint(ctx context.Context, checkpoint *BackfillCheckpoint) error {
+	logger := utils.LoggerWithContext(ctx, b.logger).With(zap.Any("checkpoint", checkpoint))
+
+	checkpoint.UpdatedAt = time.Now()
+	result := b.database.WithContext(ctx).Save(checkpoint)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to save backfill checkpoint")
+		return result.Error
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -24,7 +26,7 @@ func NewDatabase(dbConfig configs.Database) (Database, func(), error) {
 		return Database{}, nil, err
 	}
 
-	db, cleanup, err := NewGORMDatabase(dbConfig)
+	db, cleanup, err := newGORMDatabase(dbConfig, "data_accessor")
 	if err != nil {
 		return Database{}, nil, err
 	}
@@ -34,7 +36,45 @@ func NewDatabase(dbConfig configs.Database) (Database, func(), error) {
 	}, cleanup, nil
 }
 
+// Ping checks that the database can be reached.
+func (d Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
+// WithAdvisoryLock runs fn while holding the session level advisory lock key, on a connection of its own.
+// It returns false without running fn if the lock is held by another session.
+func (d Database) WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	locked := false
+	err := d.DB.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Raw("SELECT pg_try_advisory_lock(?)", key).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+		defer func() {
+			// The lock outlives a cancelled ctx otherwise, until the connection is closed.
+			conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(?)", key)
+		}()
+
+		return fn(ctx)
+	})
+
+	return locked, err
+}
+
 func NewGORMDatabase(dbConfig configs.Database) (*gorm.DB, func(), error) {
+	return newGORMDatabase(dbConfig, "transaction")
+}
+
+// newGORMDatabase opens a connection pool and exports its stats as metrics, labelled with poolName since
+// the data accessors and the transactions of the logic layer each have their own pool.
+func newGORMDatabase(dbConfig configs.Database, poolName string) (*gorm.DB, func(), error) {
 	// Create data source name (DSN) string
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Shanghai", dbConfig.Host, dbConfig.Username, dbConfig.Password, dbConfig.Database, dbConfig.Port)
 
@@ -49,8 +89,19 @@ func NewGORMDatabase(dbConfig configs.Database) (*gorm.DB, func(), error) {
 		return nil, nil, err
 	}
 
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statsCollector := collectors.NewDBStatsCollector(sqlDB, fmt.Sprintf("%s/%s", dbConfig.Database, poolName))
+	if err := prometheus.Register(statsCollector); err != nil {
+		sqlDB.Close()
+		return nil, nil, err
+	}
+
 	cleanup := func() {
-		sqlDB, _ := db.DB()
+		prometheus.Unregister(statsCollector)
 		sqlDB.Close()
 	}
 
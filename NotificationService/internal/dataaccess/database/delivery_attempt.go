@@ -0,0 +1,93 @@
+package database
+
+import (
+	"NotificationService/internal/utils"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DeliveryChannelEmail = "email"
+)
+
+type DeliveryAttemptStatus uint8
+
+const (
+	DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_SUCCESS DeliveryAttemptStatus = 1
+	DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_FAILED  DeliveryAttemptStatus = 2
+)
+
+// DeliveryAttempt records a single attempt to deliver a notification, whatever its outcome, so that the
+// delivery history of a booking can be audited.
+type DeliveryAttempt struct {
+	ID               uint32                `gorm:"column:delivery_attempt_id;primaryKey"`
+	OfNotificationId uint32                `gorm:"column:of_notification_id"`
+	OfBookingId      uint32                `gorm:"column:of_booking_id"`
+	Channel          string                `gorm:"column:channel"`
+	Recipient        string                `gorm:"column:recipient"`
+	Status           DeliveryAttemptStatus `gorm:"column:status"`
+	MessageID        string                `gorm:"column:message_id"`
+	TemplateVersion  string                `gorm:"column:template_version"`
+	ErrorClass       string                `gorm:"column:error_class"`
+	ProviderResponse string                `gorm:"column:provider_response"`
+	StartedAt        time.Time             `gorm:"column:started_at"`
+	FinishedAt       time.Time             `gorm:"column:finished_at"`
+}
+
+func (DeliveryAttempt) TableName() string {
+	return "notification_service_notification_delivery_attempt_tab"
+}
+
+type DeliveryAttemptDataAccessor interface {
+	CreateDeliveryAttempt(ctx context.Context, deliveryAttempt *DeliveryAttempt) (*DeliveryAttempt, error)
+	// GetDeliveryAttemptListByBookingId returns the delivery attempts of a booking, oldest first.
+	GetDeliveryAttemptListByBookingId(ctx context.Context, bookingId uint32) ([]*DeliveryAttempt, error)
+}
+
+type deliveryAttemptDataAccessor struct {
+	database Database
+	logger   *zap.Logger
+}
+
+func NewDeliveryAttemptDataAccessor(database Database, logger *zap.Logger) DeliveryAttemptDataAccessor {
+	return &deliveryAttemptDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+func (d deliveryAttemptDataAccessor) CreateDeliveryAttempt(
+	ctx context.Context,
+	deliveryAttempt *DeliveryAttempt,
+) (*DeliveryAttempt, error) {
+	logger := utils.LoggerWithContext(ctx, d.logger).With(zap.Any("delivery_attempt", deliveryAttempt))
+
+	result := d.database.WithContext(ctx).Create(deliveryAttempt)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to create delivery attempt")
+		return nil, result.Error
+	}
+
+	return deliveryAttempt, nil
+}
+
+func (d deliveryAttemptDataAccessor) GetDeliveryAttemptListByBookingId(
+	ctx context.Context,
+	bookingId uint32,
+) ([]*DeliveryAttempt, error) {
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, bookingId), d.logger)
+
+	var deliveryAttempts []*DeliveryAttempt
+	result := d.database.WithContext(ctx).
+		Where("of_booking_id = ?", bookingId).
+		Order("started_at, delivery_attempt_id").
+		Find(&deliveryAttempts)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get delivery attempt list by booking id")
+		return nil, result.Error
+	}
+
+	return deliveryAttempts, nil
+}
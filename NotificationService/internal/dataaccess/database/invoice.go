@@ -0,0 +1,208 @@
+package database
+
+import (
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvoiceAlreadyExists = errors.New("invoice already exists")
+)
+
+type Invoice struct {
+	ID          uint32 `gorm:"column:invoice_id;primaryKey"`
+	OfBookingId uint32 `gorm:"column:of_booking_id"`
+	IssuerId    string `gorm:"column:issuer_id"`
+	Series      string `gorm:"column:series"`
+	// Year and Number are allocated by CreateInvoice, numbers are sequential per issuer and year.
+	Year               uint32    `gorm:"column:year"`
+	Number             uint32    `gorm:"column:number"`
+	IssuedAt           time.Time `gorm:"column:issued_at"`
+	SellerName         string    `gorm:"column:seller_name"`
+	SellerTaxId        string    `gorm:"column:seller_tax_id"`
+	SellerAddress      string    `gorm:"column:seller_address"`
+	Currency           string    `gorm:"column:currency"`
+	TaxName            string    `gorm:"column:tax_name"`
+	TaxRateBasisPoints uint32    `gorm:"column:tax_rate_basis_points"`
+	NetAmount          uint64    `gorm:"column:net_amount"`
+	TaxAmount          uint64    `gorm:"column:tax_amount"`
+	GrossAmount        uint64    `gorm:"column:gross_amount"`
+	// VoidedAt is when the invoice was voided, it is nil while the invoice bills its bookings.
+	VoidedAt *time.Time `gorm:"column:voided_at"`
+}
+
+func (Invoice) TableName() string {
+	return "notification_service_invoice_tab"
+}
+
+// InvoiceNo is the number printed on the invoice.
+func (i Invoice) InvoiceNo() string {
+	return fmt.Sprintf("%s-%d-%07d", i.Series, i.Year, i.Number)
+}
+
+// InvoiceLine is the ticket of a booking on an invoice, an invoice has a line per booking of its group.
+type InvoiceLine struct {
+	ID          uint32 `gorm:"column:invoice_line_id;primaryKey"`
+	OfInvoiceId uint32 `gorm:"column:of_invoice_id"`
+	OfBookingId uint32 `gorm:"column:of_booking_id"`
+	NetAmount   uint64 `gorm:"column:net_amount"`
+	TaxAmount   uint64 `gorm:"column:tax_amount"`
+	GrossAmount uint64 `gorm:"column:gross_amount"`
+	Voided      bool   `gorm:"column:voided"`
+}
+
+func (InvoiceLine) TableName() string {
+	return "notification_service_invoice_line_tab"
+}
+
+type InvoiceSequence struct {
+	IssuerId   string `gorm:"column:issuer_id;primaryKey"`
+	Year       uint32 `gorm:"column:year;primaryKey"`
+	LastNumber uint32 `gorm:"column:last_number"`
+}
+
+func (InvoiceSequence) TableName() string {
+	return "notification_service_invoice_sequence_tab"
+}
+
+type InvoiceDataAccessor interface {
+	// CreateInvoice allocates the next number of the issuer and year of invoice and creates it with its
+	// lines, in the same transaction so that numbers are gap-free. It returns ErrInvoiceAlreadyExists if one
+	// of the bookings already has an invoice, the number allocated for it is then released.
+	CreateInvoice(ctx context.Context, invoice *Invoice, invoiceLines []*InvoiceLine) (*Invoice, error)
+	// GetInvoiceByBookingIds returns the invoice that is not voided and has a line for one of bookingIds, or
+	// is filed under one of them, or gorm.ErrRecordNotFound if there is none.
+	GetInvoiceByBookingIds(ctx context.Context, bookingIds []uint32) (*Invoice, error)
+	GetInvoiceLineListByInvoiceId(ctx context.Context, invoiceId uint32) ([]*InvoiceLine, error)
+	// VoidInvoice voids the invoice and its lines, so that their bookings can be invoiced again.
+	VoidInvoice(ctx context.Context, invoiceId uint32, voidedAt time.Time) error
+}
+
+type invoiceDataAccessor struct {
+	database Database
+	logger   *zap.Logger
+}
+
+func NewInvoiceDataAccessor(database Database, logger *zap.Logger) InvoiceDataAccessor {
+	return &invoiceDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+func (i invoiceDataAccessor) CreateInvoice(
+	ctx context.Context,
+	invoice *Invoice,
+	invoiceLines []*InvoiceLine,
+) (*Invoice, error) {
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, invoice.OfBookingId), i.logger).
+		With(zap.String("issuer_id", invoice.IssuerId)).
+		With(zap.Uint32("year", invoice.Year))
+
+	err := i.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The sequence row stays locked until the transaction ends, so concurrent invoices of the same issuer
+		// and year are numbered one after the other.
+		var sequence InvoiceSequence
+		if err := tx.Raw(
+			`INSERT INTO notification_service_invoice_sequence_tab (issuer_id, year, last_number) VALUES (?, ?, 1)
+			ON CONFLICT (issuer_id, year) DO UPDATE SET last_number = notification_service_invoice_sequence_tab.last_number + 1
+			RETURNING issuer_id, year, last_number`,
+			invoice.IssuerId,
+			invoice.Year,
+		).Scan(&sequence).Error; err != nil {
+			return err
+		}
+
+		invoice.Number = sequence.LastNumber
+		if err := tx.Create(invoice).Error; err != nil {
+			return err
+		}
+
+		if len(invoiceLines) == 0 {
+			return nil
+		}
+		for _, invoiceLine := range invoiceLines {
+			invoiceLine.OfInvoiceId = invoice.ID
+		}
+		return tx.Create(invoiceLines).Error
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgErrorCodeUniqueViolation {
+		invoice.ID, invoice.Number = 0, 0
+		return nil, ErrInvoiceAlreadyExists
+	}
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create invoice")
+		invoice.ID, invoice.Number = 0, 0
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (i invoiceDataAccessor) GetInvoiceByBookingIds(ctx context.Context, bookingIds []uint32) (*Invoice, error) {
+	logger := utils.LoggerWithContext(ctx, i.logger).With(zap.Uint32s("booking_ids", bookingIds))
+
+	// Invoices issued before invoices had lines are only filed under their booking.
+	var invoice Invoice
+	result := i.database.WithContext(ctx).
+		Where("voided_at IS NULL").
+		Where(
+			`(invoice_id IN (SELECT of_invoice_id FROM notification_service_invoice_line_tab WHERE of_booking_id IN ? AND NOT voided)
+			OR of_booking_id IN ?)`,
+			bookingIds,
+			bookingIds,
+		).
+		Order("invoice_id").
+		First(&invoice)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get invoice by booking ids")
+		return nil, result.Error
+	}
+
+	return &invoice, nil
+}
+
+func (i invoiceDataAccessor) GetInvoiceLineListByInvoiceId(ctx context.Context, invoiceId uint32) ([]*InvoiceLine, error) {
+	logger := utils.LoggerWithContext(ctx, i.logger).With(zap.Uint32("invoice_id", invoiceId))
+
+	var invoiceLines []*InvoiceLine
+	result := i.database.WithContext(ctx).
+		Where("of_invoice_id = ?", invoiceId).
+		Order("invoice_line_id").
+		Find(&invoiceLines)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get invoice line list by invoice id")
+		return nil, result.Error
+	}
+
+	return invoiceLines, nil
+}
+
+func (i invoiceDataAccessor) VoidInvoice(ctx context.Context, invoiceId uint32, voidedAt time.Time) error {
+	logger := utils.LoggerWithContext(ctx, i.logger).With(zap.Uint32("invoice_id", invoiceId))
+
+	err := i.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Invoice{}).Where("invoice_id = ?", invoiceId).Update("voided_at", voidedAt).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&InvoiceLine{}).Where("of_invoice_id = ?", invoiceId).Update("voided", true).Error
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to void invoice")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package database
+
+import "testing"
+
+func TestInvoiceInvoiceNo(t *testing.T) {
+	testCases := []struct {
+		name    string
+		invoice Invoice
+		want    string
+	}{
+		{
+			name:    "first invoice of the year",
+			invoice: Invoice{Series: "C26T", Year: 2026, Number: 1},
+			want:    "C26T-2026-0000001",
+		},
+		{
+			name:    "number padded to seven digits",
+			invoice: Invoice{Series: "HN", Year: 2026, Number: 4213},
+			want:    "HN-2026-0004213",
+		},
+		{
+			name:    "number longer than seven digits",
+			invoice: Invoice{Series: "HN", Year: 2027, Number: 12345678},
+			want:    "HN-2027-12345678",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := testCase.invoice.InvoiceNo(); got != testCase.want {
+				t.Errorf("InvoiceNo() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS notification_service_notification_retry_at_idx;
+
+ALTER TABLE notification_service_notification_tab DROP COLUMN IF EXISTS retry_at;
@@ -0,0 +1,4 @@
+-- Deferred notifications are enqueued again by the scheduler once retry_at has passed.
+ALTER TABLE notification_service_notification_tab ADD COLUMN IF NOT EXISTS retry_at TIMESTAMP NULL;
+
+CREATE INDEX notification_service_notification_retry_at_idx ON notification_service_notification_tab (retry_at) WHERE retry_at IS NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS notification_service_backfill_checkpoint_tab;
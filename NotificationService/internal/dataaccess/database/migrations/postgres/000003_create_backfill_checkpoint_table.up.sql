@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS notification_service_backfill_checkpoint_tab (
+    backfill_id VARCHAR(255) PRIMARY KEY,
+    next_user_offset INT NOT NULL DEFAULT 0,
+    processed_booking_count INT NOT NULL DEFAULT 0,
+    skipped_booking_count INT NOT NULL DEFAULT 0,
+    failed_booking_count INT NOT NULL DEFAULT 0,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
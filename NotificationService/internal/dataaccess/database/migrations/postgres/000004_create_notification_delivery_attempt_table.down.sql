@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS notification_service_notification_delivery_attempt_booking_idx;
+
+DROP TABLE IF EXISTS notification_service_notification_delivery_attempt_tab;
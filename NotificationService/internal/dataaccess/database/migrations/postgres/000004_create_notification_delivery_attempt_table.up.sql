@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS notification_service_notification_delivery_attempt_tab (
+    delivery_attempt_id SERIAL PRIMARY KEY,
+    of_notification_id INT NOT NULL,
+    of_booking_id INT NOT NULL,
+    channel VARCHAR(32) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    status SMALLINT NOT NULL,
+    message_id VARCHAR(255) NOT NULL DEFAULT '',
+    template_version VARCHAR(64) NOT NULL DEFAULT '',
+    error_class VARCHAR(32) NOT NULL DEFAULT '',
+    provider_response TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX notification_service_notification_delivery_attempt_booking_idx ON notification_service_notification_delivery_attempt_tab (of_booking_id, started_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS notification_service_ticket_check_in_showtime_idx;
+
+DROP TABLE IF EXISTS notification_service_ticket_check_in_tab;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS notification_service_ticket_check_in_tab (
+    check_in_id SERIAL PRIMARY KEY,
+    of_booking_id INT UNIQUE NOT NULL,
+    of_showtime_id INT NOT NULL,
+    of_seat_id INT NOT NULL,
+    gate VARCHAR(64) NOT NULL,
+    staff_id INT NOT NULL,
+    checked_in_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX notification_service_ticket_check_in_showtime_idx ON notification_service_ticket_check_in_tab (of_showtime_id);
@@ -0,0 +1 @@
+ALTER TABLE notification_service_notification_tab DROP COLUMN IF EXISTS layout_version;
@@ -0,0 +1 @@
+ALTER TABLE notification_service_notification_tab ADD COLUMN IF NOT EXISTS layout_version VARCHAR(64) NOT NULL DEFAULT '';
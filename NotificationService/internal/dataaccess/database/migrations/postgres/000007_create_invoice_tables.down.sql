@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_service_invoice_tab;
+
+DROP TABLE IF EXISTS notification_service_invoice_sequence_tab;
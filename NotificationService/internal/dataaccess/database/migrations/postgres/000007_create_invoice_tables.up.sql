@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS notification_service_invoice_sequence_tab (
+    issuer_id VARCHAR(64) NOT NULL,
+    year INT NOT NULL,
+    last_number INT NOT NULL,
+    PRIMARY KEY (issuer_id, year)
+);
+
+CREATE TABLE IF NOT EXISTS notification_service_invoice_tab (
+    invoice_id SERIAL PRIMARY KEY,
+    of_booking_id INT UNIQUE NOT NULL,
+    issuer_id VARCHAR(64) NOT NULL,
+    series VARCHAR(32) NOT NULL,
+    year INT NOT NULL,
+    number INT NOT NULL,
+    issued_at TIMESTAMP NOT NULL,
+    seller_name VARCHAR(255) NOT NULL,
+    seller_tax_id VARCHAR(32) NOT NULL,
+    seller_address VARCHAR(255) NOT NULL,
+    currency VARCHAR(8) NOT NULL,
+    tax_name VARCHAR(32) NOT NULL,
+    tax_rate_basis_points INT NOT NULL,
+    net_amount BIGINT NOT NULL,
+    tax_amount BIGINT NOT NULL,
+    gross_amount BIGINT NOT NULL,
+    UNIQUE (issuer_id, year, number)
+);
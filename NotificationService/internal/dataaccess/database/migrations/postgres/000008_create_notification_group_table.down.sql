@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS notification_service_invoice_line_invoice_idx;
+
+DROP TABLE IF EXISTS notification_service_invoice_line_tab;
+
+-- The unique constraint on original_pdf_filename is not restored: the notifications of a group share its PDF,
+-- and picking which of them keeps it would lose the PDF of the others.
+
+DROP INDEX IF EXISTS notification_service_notification_group_idx;
+
+ALTER TABLE notification_service_notification_tab DROP COLUMN IF EXISTS of_notification_group_id;
+
+DROP INDEX IF EXISTS notification_service_notification_group_user_showtime_idx;
+
+DROP TABLE IF EXISTS notification_service_notification_group_tab;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS notification_service_notification_group_tab (
+    notification_group_id SERIAL PRIMARY KEY,
+    of_user_id INT NOT NULL,
+    of_showtime_id INT NOT NULL,
+    status SMALLINT NOT NULL,
+    window_ends_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX notification_service_notification_group_user_showtime_idx ON notification_service_notification_group_tab (of_user_id, of_showtime_id, window_ends_at);
+
+ALTER TABLE notification_service_notification_tab ADD COLUMN IF NOT EXISTS of_notification_group_id INT NOT NULL DEFAULT 0;
+
+CREATE INDEX notification_service_notification_group_idx ON notification_service_notification_tab (of_notification_group_id);
+
+-- The notifications of a group share its PDF.
+ALTER TABLE notification_service_notification_tab DROP CONSTRAINT IF EXISTS notification_service_notification_tab_original_pdf_filename_key;
+
+CREATE TABLE IF NOT EXISTS notification_service_invoice_line_tab (
+    invoice_line_id SERIAL PRIMARY KEY,
+    of_invoice_id INT NOT NULL,
+    of_booking_id INT UNIQUE NOT NULL,
+    net_amount BIGINT NOT NULL,
+    tax_amount BIGINT NOT NULL,
+    gross_amount BIGINT NOT NULL
+);
+
+CREATE INDEX notification_service_invoice_line_invoice_idx ON notification_service_invoice_line_tab (of_invoice_id);
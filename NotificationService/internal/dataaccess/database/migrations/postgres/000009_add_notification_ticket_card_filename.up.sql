@@ -0,0 +1 @@
+ALTER TABLE notification_service_notification_tab ADD COLUMN IF NOT EXISTS ticket_card_filename VARCHAR(255) NOT NULL DEFAULT '';
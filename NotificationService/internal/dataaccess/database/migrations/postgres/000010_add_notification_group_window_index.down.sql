@@ -0,0 +1 @@
+DROP INDEX IF EXISTS notification_service_notification_group_window_idx;
@@ -0,0 +1,2 @@
+-- The scheduler closes the pending groups whose window ended.
+CREATE INDEX notification_service_notification_group_window_idx ON notification_service_notification_group_tab (window_ends_at) WHERE status = 0;
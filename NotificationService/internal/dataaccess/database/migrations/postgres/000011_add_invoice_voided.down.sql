@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS notification_service_invoice_line_booking_idx;
+
+ALTER TABLE notification_service_invoice_line_tab ADD CONSTRAINT notification_service_invoice_line_tab_of_booking_id_key UNIQUE (of_booking_id);
+
+ALTER TABLE notification_service_invoice_line_tab DROP COLUMN IF EXISTS voided;
+
+DROP INDEX IF EXISTS notification_service_invoice_booking_idx;
+
+ALTER TABLE notification_service_invoice_tab ADD CONSTRAINT notification_service_invoice_tab_of_booking_id_key UNIQUE (of_booking_id);
+
+ALTER TABLE notification_service_invoice_tab DROP COLUMN IF EXISTS voided_at;
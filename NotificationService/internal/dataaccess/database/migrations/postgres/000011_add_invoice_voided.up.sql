@@ -0,0 +1,12 @@
+-- A voided invoice no longer bills its bookings, which can then be invoiced again.
+ALTER TABLE notification_service_invoice_tab ADD COLUMN IF NOT EXISTS voided_at TIMESTAMP NULL;
+
+ALTER TABLE notification_service_invoice_tab DROP CONSTRAINT IF EXISTS notification_service_invoice_tab_of_booking_id_key;
+
+CREATE UNIQUE INDEX notification_service_invoice_booking_idx ON notification_service_invoice_tab (of_booking_id) WHERE voided_at IS NULL;
+
+ALTER TABLE notification_service_invoice_line_tab ADD COLUMN IF NOT EXISTS voided BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE notification_service_invoice_line_tab DROP CONSTRAINT IF EXISTS notification_service_invoice_line_tab_of_booking_id_key;
+
+CREATE UNIQUE INDEX notification_service_invoice_line_booking_idx ON notification_service_invoice_line_tab (of_booking_id) WHERE NOT voided;
@@ -1,11 +1,14 @@
 package database
 
 import (
+	"NotificationService/internal/utils"
 	"context"
 	"errors"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type NotificationStatus uint8
@@ -40,10 +43,15 @@ func (x NotificationStatus) Enum() *NotificationStatus {
 }
 
 type Notification struct {
-	ID                  uint32             `gorm:"column:notification_id;primaryKey"`
-	OfBookingId         uint32             `gorm:"column:of_booking_id"`
-	Status              NotificationStatus `gorm:"column:status"`
-	OriginalPDFFilename string             `gorm:"column:original_pdf_filename"`
+	ID                    uint32             `gorm:"column:notification_id;primaryKey"`
+	OfBookingId           uint32             `gorm:"column:of_booking_id"`
+	Status                NotificationStatus `gorm:"column:status"`
+	OriginalPDFFilename   string             `gorm:"column:original_pdf_filename"`
+	LayoutVersion         string             `gorm:"column:layout_version"`
+	OfNotificationGroupId uint32             `gorm:"column:of_notification_group_id"`
+	TicketCardFilename    string             `gorm:"column:ticket_card_filename"`
+	// RetryAt is when a deferred notification is enqueued again, it is nil otherwise.
+	RetryAt *time.Time `gorm:"column:retry_at"`
 }
 
 func (Notification) TableName() string {
@@ -55,8 +63,18 @@ type NotificationDataAccessor interface {
 	UpdateNotification(ctx context.Context, notification *Notification) (*Notification, error)
 	GetNotificationById(ctx context.Context, id uint32) (*Notification, error)
 	GetNotificationByIdWithXLock(ctx context.Context, id uint32) (*Notification, error)
+	GetNotificationByBookingId(ctx context.Context, bookingId uint32) (*Notification, error)
 	GetNotificationListByStatus(ctx context.Context, status NotificationStatus) ([]*Notification, error)
+	// GetNotificationList pages through the notifications ordered by booking, so that the notifications of a
+	// booking are next to each other.
+	GetNotificationList(ctx context.Context, offset uint32, limit uint32) ([]*Notification, error)
+	// GetNotificationListByBookingIdList returns the notifications of the bookings in bookingIdList, ordered by id.
+	GetNotificationListByBookingIdList(ctx context.Context, bookingIdList []uint32) ([]*Notification, error)
 	GetNotificationCount(ctx context.Context, status uint32) (uint32, error)
+	GetNotificationListByGroupIdWithXLock(ctx context.Context, groupId uint32) ([]*Notification, error)
+	// GetDueNotificationListWithXLock returns up to limit pending notifications whose retry is due at now,
+	// skipping the ones locked by another transaction so that concurrent schedulers do not enqueue them twice.
+	GetDueNotificationListWithXLock(ctx context.Context, now time.Time, limit int) ([]*Notification, error)
 	WithDB(db *gorm.DB) NotificationDataAccessor
 }
 
@@ -73,7 +91,7 @@ func NewNotificationDataAccessor(database Database, logger *zap.Logger) Notifica
 }
 
 func (n notificationDataAccessor) CreateNotification(ctx context.Context, notification *Notification) (*Notification, error) {
-	logger := n.logger.With(zap.Any("notification", notification))
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Any("notification", notification))
 
 	result := n.database.Create(notification)
 	if result.Error != nil {
@@ -85,7 +103,7 @@ func (n notificationDataAccessor) CreateNotification(ctx context.Context, notifi
 }
 
 func (n notificationDataAccessor) UpdateNotification(ctx context.Context, notification *Notification) (*Notification, error) {
-	logger := n.logger.With(zap.Any("notification", notification))
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Any("notification", notification))
 
 	if notification.ID == 0 {
 		err := errors.New("notification ID cannot be zero")
@@ -103,7 +121,7 @@ func (n notificationDataAccessor) UpdateNotification(ctx context.Context, notifi
 }
 
 func (n notificationDataAccessor) GetNotificationById(ctx context.Context, id uint32) (*Notification, error) {
-	logger := n.logger.With(zap.Uint32("id", id))
+	logger := utils.LoggerWithContext(utils.ContextWithNotificationID(ctx, id), n.logger)
 
 	var notification Notification
 	result := n.database.First(&notification, id)
@@ -116,7 +134,7 @@ func (n notificationDataAccessor) GetNotificationById(ctx context.Context, id ui
 }
 
 func (n notificationDataAccessor) GetNotificationByIdWithXLock(ctx context.Context, id uint32) (*Notification, error) {
-	logger := n.logger.With(zap.Uint32("id", id))
+	logger := utils.LoggerWithContext(utils.ContextWithNotificationID(ctx, id), n.logger)
 
 	var notification Notification
 	result := n.database.Set("gorm:query_option", "FOR UPDATE").First(&notification, id)
@@ -128,8 +146,21 @@ func (n notificationDataAccessor) GetNotificationByIdWithXLock(ctx context.Conte
 	return &notification, nil
 }
 
+func (n notificationDataAccessor) GetNotificationByBookingId(ctx context.Context, bookingId uint32) (*Notification, error) {
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, bookingId), n.logger)
+
+	var notification Notification
+	result := n.database.Where("of_booking_id = ?", bookingId).First(&notification)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get notification by booking id")
+		return nil, result.Error
+	}
+
+	return &notification, nil
+}
+
 func (n notificationDataAccessor) GetNotificationListByStatus(ctx context.Context, status NotificationStatus) ([]*Notification, error) {
-	logger := n.logger.With(zap.Uint8("status", uint8(status)))
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint8("status", uint8(status)))
 
 	var notifications []*Notification
 	result := n.database.Where("status = ?", status).Find(&notifications)
@@ -141,8 +172,37 @@ func (n notificationDataAccessor) GetNotificationListByStatus(ctx context.Contex
 	return notifications, nil
 }
 
+func (n notificationDataAccessor) GetNotificationList(ctx context.Context, offset uint32, limit uint32) ([]*Notification, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint32("offset", offset)).With(zap.Uint32("limit", limit))
+
+	var notifications []*Notification
+	result := n.database.Order("of_booking_id, notification_id").Offset(int(offset)).Limit(int(limit)).Find(&notifications)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get notification list")
+		return nil, result.Error
+	}
+
+	return notifications, nil
+}
+
+func (n notificationDataAccessor) GetNotificationListByBookingIdList(
+	ctx context.Context,
+	bookingIdList []uint32,
+) ([]*Notification, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Int("booking_count", len(bookingIdList)))
+
+	var notifications []*Notification
+	result := n.database.Where("of_booking_id IN ?", bookingIdList).Order("notification_id").Find(&notifications)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get notification list by booking id list")
+		return nil, result.Error
+	}
+
+	return notifications, nil
+}
+
 func (n notificationDataAccessor) GetNotificationCount(ctx context.Context, bookingId uint32) (uint32, error) {
-	logger := n.logger.With(zap.Uint32("notification_with_booking_id", bookingId))
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, bookingId), n.logger)
 
 	count := int64(0)
 	if err := n.database.Model(&Notification{}).Where("of_booking_id = ?", bookingId).Count(&count).Error; err != nil {
@@ -153,9 +213,51 @@ func (n notificationDataAccessor) GetNotificationCount(ctx context.Context, book
 	return uint32(count), nil
 }
 
+func (n notificationDataAccessor) GetNotificationListByGroupIdWithXLock(
+	ctx context.Context,
+	groupId uint32,
+) ([]*Notification, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint32("notification_group_id", groupId))
+
+	var notifications []*Notification
+	result := n.database.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("of_notification_group_id = ?", groupId).
+		Order("notification_id").
+		Find(&notifications)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get notification list by group id with x lock")
+		return nil, result.Error
+	}
+
+	return notifications, nil
+}
+
+func (n notificationDataAccessor) GetDueNotificationListWithXLock(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*Notification, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Time("now", now))
+
+	var notifications []*Notification
+	result := n.database.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND retry_at <= ?", NotificationStatus_NOTIFICATION_STATUS_PENDING, now).
+		Order("retry_at").
+		Limit(limit).
+		Find(&notifications)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get due notification list with x lock")
+		return nil, result.Error
+	}
+
+	return notifications, nil
+}
+
 func (n notificationDataAccessor) WithDB(db *gorm.DB) NotificationDataAccessor {
 	return &notificationDataAccessor{
-		database: n.database,
+		database: Database{DB: db},
 		logger:   n.logger,
 	}
 }
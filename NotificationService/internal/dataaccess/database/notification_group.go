@@ -0,0 +1,188 @@
+package database
+
+import (
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationGroup gathers the notifications of the bookings of a user for the same showtime, completed
+// within a short window, so that they are sent as one email with one PDF.
+type NotificationGroup struct {
+	ID           uint32             `gorm:"column:notification_group_id;primaryKey"`
+	OfUserId     uint32             `gorm:"column:of_user_id"`
+	OfShowtimeId uint32             `gorm:"column:of_showtime_id"`
+	Status       NotificationStatus `gorm:"column:status"`
+	WindowEndsAt time.Time          `gorm:"column:window_ends_at"`
+}
+
+func (NotificationGroup) TableName() string {
+	return "notification_service_notification_group_tab"
+}
+
+type NotificationGroupDataAccessor interface {
+	CreateNotificationGroup(ctx context.Context, notificationGroup *NotificationGroup) (*NotificationGroup, error)
+	UpdateNotificationGroup(ctx context.Context, notificationGroup *NotificationGroup) (*NotificationGroup, error)
+	GetNotificationGroupById(ctx context.Context, id uint32) (*NotificationGroup, error)
+	GetNotificationGroupByIdWithXLock(ctx context.Context, id uint32) (*NotificationGroup, error)
+	// GetOpenNotificationGroupWithXLock returns the pending group of a user and showtime whose window ends
+	// after now, or gorm.ErrRecordNotFound if there is none. It must be called in a transaction, which holds
+	// a lock on the groups of the user and showtime until it ends, so that concurrent bookings do not open
+	// two groups.
+	GetOpenNotificationGroupWithXLock(
+		ctx context.Context,
+		userId uint32,
+		showtimeId uint32,
+		now time.Time,
+	) (*NotificationGroup, error)
+	// GetDueNotificationGroupListWithXLock returns up to limit pending groups whose window ended at now,
+	// skipping the ones locked by another transaction so that concurrent schedulers do not close them twice.
+	GetDueNotificationGroupListWithXLock(ctx context.Context, now time.Time, limit int) ([]*NotificationGroup, error)
+	WithDB(db *gorm.DB) NotificationGroupDataAccessor
+}
+
+type notificationGroupDataAccessor struct {
+	database Database
+	logger   *zap.Logger
+}
+
+func NewNotificationGroupDataAccessor(database Database, logger *zap.Logger) NotificationGroupDataAccessor {
+	return &notificationGroupDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+func (n notificationGroupDataAccessor) CreateNotificationGroup(
+	ctx context.Context,
+	notificationGroup *NotificationGroup,
+) (*NotificationGroup, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Any("notification_group", notificationGroup))
+
+	result := n.database.WithContext(ctx).Create(notificationGroup)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to create notification group")
+		return nil, result.Error
+	}
+
+	return notificationGroup, nil
+}
+
+func (n notificationGroupDataAccessor) UpdateNotificationGroup(
+	ctx context.Context,
+	notificationGroup *NotificationGroup,
+) (*NotificationGroup, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Any("notification_group", notificationGroup))
+
+	if notificationGroup.ID == 0 {
+		err := errors.New("notification group ID cannot be zero")
+		logger.With(zap.Error(err)).Error("invalid notification group ID")
+		return nil, err
+	}
+
+	result := n.database.WithContext(ctx).Save(notificationGroup)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to update notification group")
+		return nil, result.Error
+	}
+
+	return notificationGroup, nil
+}
+
+func (n notificationGroupDataAccessor) GetNotificationGroupById(ctx context.Context, id uint32) (*NotificationGroup, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint32("notification_group_id", id))
+
+	var notificationGroup NotificationGroup
+	result := n.database.WithContext(ctx).First(&notificationGroup, id)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get notification group")
+		return nil, result.Error
+	}
+
+	return &notificationGroup, nil
+}
+
+func (n notificationGroupDataAccessor) GetNotificationGroupByIdWithXLock(
+	ctx context.Context,
+	id uint32,
+) (*NotificationGroup, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint32("notification_group_id", id))
+
+	var notificationGroup NotificationGroup
+	result := n.database.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&notificationGroup, id)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get notification group with x lock")
+		return nil, result.Error
+	}
+
+	return &notificationGroup, nil
+}
+
+func (n notificationGroupDataAccessor) GetOpenNotificationGroupWithXLock(
+	ctx context.Context,
+	userId uint32,
+	showtimeId uint32,
+	now time.Time,
+) (*NotificationGroup, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).
+		With(zap.Uint32(utils.LogFieldUserID, userId)).
+		With(zap.Uint32("showtime_id", showtimeId))
+
+	// A new group has no row to lock yet, the advisory lock serializes the bookings of the user and showtime
+	// instead. Ids are reinterpreted as the 32-bit keys of the lock.
+	if err := n.database.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(?, ?)", int32(userId), int32(showtimeId)).Error; err != nil {
+		logger.With(zap.Error(err)).Error("failed to lock notification groups")
+		return nil, err
+	}
+
+	var notificationGroup NotificationGroup
+	result := n.database.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("of_user_id = ? AND of_showtime_id = ?", userId, showtimeId).
+		Where("status = ? AND window_ends_at > ?", NotificationStatus_NOTIFICATION_STATUS_PENDING, now).
+		Order("notification_group_id DESC").
+		First(&notificationGroup)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get open notification group")
+		return nil, result.Error
+	}
+
+	return &notificationGroup, nil
+}
+
+func (n notificationGroupDataAccessor) GetDueNotificationGroupListWithXLock(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*NotificationGroup, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Time("now", now))
+
+	var notificationGroups []*NotificationGroup
+	result := n.database.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND window_ends_at <= ?", NotificationStatus_NOTIFICATION_STATUS_PENDING, now).
+		Order("window_ends_at").
+		Limit(limit).
+		Find(&notificationGroups)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get due notification group list with x lock")
+		return nil, result.Error
+	}
+
+	return notificationGroups, nil
+}
+
+func (n notificationGroupDataAccessor) WithDB(db *gorm.DB) NotificationGroupDataAccessor {
+	return &notificationGroupDataAccessor{
+		database: Database{DB: db},
+		logger:   n.logger,
+	}
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	pgErrorCodeUniqueViolation = "23505"
+)
+
+var (
+	ErrTicketCheckInAlreadyExists = errors.New("ticket check-in already exists")
+)
+
+type TicketCheckIn struct {
+	ID           uint32    `gorm:"column:check_in_id;primaryKey"`
+	OfBookingId  uint32    `gorm:"column:of_booking_id"`
+	OfShowtimeId uint32    `gorm:"column:of_showtime_id"`
+	OfSeatId     uint32    `gorm:"column:of_seat_id"`
+	Gate         string    `gorm:"column:gate"`
+	StaffId      uint32    `gorm:"column:staff_id"`
+	CheckedInAt  time.Time `gorm:"column:checked_in_at"`
+}
+
+func (TicketCheckIn) TableName() string {
+	return "notification_service_ticket_check_in_tab"
+}
+
+type TicketCheckInDataAccessor interface {
+	// CreateTicketCheckIn returns ErrTicketCheckInAlreadyExists if the booking has already been checked in.
+	CreateTicketCheckIn(ctx context.Context, ticketCheckIn *TicketCheckIn) (*TicketCheckIn, error)
+	// GetTicketCheckInByBookingId returns gorm.ErrRecordNotFound if the booking has not been checked in.
+	GetTicketCheckInByBookingId(ctx context.Context, bookingId uint32) (*TicketCheckIn, error)
+	GetTicketCheckInListByShowtimeId(ctx context.Context, showtimeId uint32) ([]*TicketCheckIn, error)
+}
+
+type ticketCheckInDataAccessor struct {
+	database Database
+	logger   *zap.Logger
+}
+
+func NewTicketCheckInDataAccessor(database Database, logger *zap.Logger) TicketCheckInDataAccessor {
+	return &ticketCheckInDataAccessor{
+		database: database,
+		logger:   logger,
+	}
+}
+
+func (t ticketCheckInDataAccessor) CreateTicketCheckIn(
+	ctx context.Context,
+	ticketCheckIn *TicketCheckIn,
+) (*TicketCheckIn, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).With(zap.Any("ticket_check_in", ticketCheckIn))
+
+	// The unique booking id rejects concurrent check-ins of the same ticket.
+	result := t.database.WithContext(ctx).Create(ticketCheckIn)
+	var pgErr *pgconn.PgError
+	if errors.As(result.Error, &pgErr) && pgErr.Code == pgErrorCodeUniqueViolation {
+		return nil, ErrTicketCheckInAlreadyExists
+	}
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to create ticket check-in")
+		return nil, result.Error
+	}
+
+	return ticketCheckIn, nil
+}
+
+func (t ticketCheckInDataAccessor) GetTicketCheckInByBookingId(ctx context.Context, bookingId uint32) (*TicketCheckIn, error) {
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, bookingId), t.logger)
+
+	var ticketCheckIn TicketCheckIn
+	result := t.database.WithContext(ctx).First(&ticketCheckIn, "of_booking_id = ?", bookingId)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get ticket check-in by booking id")
+		return nil, result.Error
+	}
+
+	return &ticketCheckIn, nil
+}
+
+func (t ticketCheckInDataAccessor) GetTicketCheckInListByShowtimeId(
+	ctx context.Context,
+	showtimeId uint32,
+) ([]*TicketCheckIn, error) {
+	logger := utils.LoggerWithContext(ctx, t.logger).With(zap.Uint32("showtime_id", showtimeId))
+
+	var ticketCheckIns []*TicketCheckIn
+	result := t.database.WithContext(ctx).
+		Where("of_showtime_id = ?", showtimeId).
+		Order("checked_in_at, check_in_id").
+		Find(&ticketCheckIns)
+	if result.Error != nil {
+		logger.With(zap.Error(result.Error)).Error("failed to get ticket check-in list by showtime id")
+		return nil, result.Error
+	}
+
+	return ticketCheckIns, nil
+}
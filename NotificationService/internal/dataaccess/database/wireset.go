@@ -4,6 +4,11 @@ import "github.com/google/wire"
 
 var WireSet = wire.NewSet(
 	NewNotificationDataAccessor,
+	NewBackfillCheckpointDataAccessor,
+	NewDeliveryAttemptDataAccessor,
+	NewTicketCheckInDataAccessor,
+	NewInvoiceDataAccessor,
+	NewNotificationGroupDataAccessor,
 	NewMigrator,
 	NewDatabase,
 	NewGORMDatabase,
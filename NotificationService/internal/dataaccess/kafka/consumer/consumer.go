@@ -2,133 +2,348 @@ package consumer
 
 import (
 	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/kafka/security"
+	"NotificationService/internal/metrics"
 	"NotificationService/internal/utils"
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"os/signal"
+	"strconv"
 	"time"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const (
+	consumeRetryDelay = 5 * time.Second
+
+	defaultHandleRetryMax     = 3
+	defaultHandleRetryBackoff = time.Second
+	maxHandleRetryBackoff     = time.Minute
+
+	tracerName = "NotificationService/internal/dataaccess/kafka/consumer"
+)
+
 type MessageHandlerFunc func(ctx context.Context, queueName string, payload []byte) error
 
+// DeadLetterFunc parks a message whose handling kept failing with reason, so that its offset can be committed.
+type DeadLetterFunc func(ctx context.Context, queueName string, key string, payload []byte, reason error) error
+
+// permanentError is a handling failure that fails again however often the message is retried.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err, returned by a MessageHandlerFunc, as a failure that retrying cannot fix, so that the
+// message is parked right away.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	return errors.As(err, new(permanentError))
+}
+
+type HandlerOption func(*queueHandler)
+
+// WithDeadLetterFunc makes messages that still fail after being retried be parked with deadLetterFunc. Without
+// it, such a message is never committed and is consumed again by the next session of the group.
+func WithDeadLetterFunc(deadLetterFunc DeadLetterFunc) HandlerOption {
+	return func(h *queueHandler) {
+		h.deadLetterFunc = deadLetterFunc
+	}
+}
+
+type queueHandler struct {
+	handlerFunc    MessageHandlerFunc
+	deadLetterFunc DeadLetterFunc
+	concurrency    int
+	retryMax       int
+	retryBackoff   time.Duration
+}
+
 type consumerHandler struct {
-	handlerFunc       MessageHandlerFunc
-	exitSignalChannel chan os.Signal
+	queueNameToHandlerMap map[string]*queueHandler
+	progress              *progressTracker
+	tracer                trace.Tracer
+	logger                *zap.Logger
 }
 
 func newConsumerHandler(
-	handlerFunc MessageHandlerFunc,
-	exitSignalChannel chan os.Signal,
+	queueNameToHandlerMap map[string]*queueHandler,
+	progress *progressTracker,
+	tracer trace.Tracer,
+	logger *zap.Logger,
 ) *consumerHandler {
 	return &consumerHandler{
-		handlerFunc:       handlerFunc,
-		exitSignalChannel: exitSignalChannel,
+		queueNameToHandlerMap: queueNameToHandlerMap,
+		progress:              progress,
+		tracer:                tracer,
+		logger:                logger,
 	}
 }
 
+// Setup is run at the beginning of a new session, once the consumer has joined the group.
 func (h consumerHandler) Setup(sarama.ConsumerGroupSession) error {
+	h.progress.SetMember(true)
 	return nil
 }
 
 // Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
 func (h consumerHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	h.progress.SetMember(false)
 	return nil
 }
 
 // ConsumeClaim must start a consumer loop of ConsumerGroupClaim's Messages().
 // Once the Messages() channel is closed, the Handler must finish its processing
 // loop and exit.
+//
+// Messages are handled concurrently by a worker pool, and the messages already dispatched when the session
+// ends are processed to completion before their offsets are committed.
 func (h consumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	handler, ok := h.queueNameToHandlerMap[claim.Topic()]
+	if !ok {
+		return fmt.Errorf("no handler registered for queue %s", claim.Topic())
+	}
+
+	pool := newPartitionWorkerPool(session, handler, h.progress, h.tracer, h.logger)
+	defer func() {
+		pool.Stop()
+		session.Commit()
+	}()
+
 	for {
 		select {
 		case message, ok := <-claim.Messages():
 			if !ok {
-				session.Commit()
 				return nil
 			}
 
-			if err := h.handlerFunc(session.Context(), message.Topic, message.Value); err != nil {
-				return err
-			}
+			metrics.KafkaConsumerLag.
+				WithLabelValues(message.Topic, strconv.Itoa(int(message.Partition))).
+				Set(float64(claim.HighWaterMarkOffset() - message.Offset - 1))
+			pool.Dispatch(message)
 
-		case <-h.exitSignalChannel:
-			session.Commit()
+		case <-session.Context().Done():
 			return nil
 		}
 	}
 }
 
 type Consumer interface {
-	RegisterHandler(queueName string, handlerFunc MessageHandlerFunc)
+	RegisterHandler(queueName string, handlerFunc MessageHandlerFunc, options ...HandlerOption)
+	// Start consumes messages until ctx is cancelled, then waits for the messages being handled to finish
+	// and leaves the consumer group.
 	Start(ctx context.Context) error
+	// Ping checks that the brokers can be reached by refreshing the cluster metadata.
+	Ping(ctx context.Context) error
+	// IsMember reports whether the consumer currently has a session in its consumer group. It is false
+	// before the consumer joined the group and during rebalances.
+	IsMember() bool
+	// CheckProgress returns an error if the consumer has had messages to handle without finishing any of them
+	// for longer than threshold. An idle consumer, or one out of its consumer group, makes progress.
+	CheckProgress(threshold time.Duration) error
 }
 
 type consumer struct {
-	saramaConsumer            sarama.ConsumerGroup
-	logger                    *zap.Logger
-	queueNameToHandlerFuncMap map[string]MessageHandlerFunc
+	saramaClient          sarama.Client
+	saramaConsumer        sarama.ConsumerGroup
+	progress              *progressTracker
+	consumerConfig        configs.KafkaConsumer
+	tracer                trace.Tracer
+	logger                *zap.Logger
+	queueNameToHandlerMap map[string]*queueHandler
 }
 
-func newSaramaConsumerConfig(kafkaConfig configs.Kafka) *sarama.Config {
+func newSaramaConsumerConfig(kafkaConfig configs.Kafka) (*sarama.Config, error) {
+	consumerConfig := kafkaConfig.Consumer
+
 	config := sarama.NewConfig()
 	config.ClientID = kafkaConfig.ClientID
 	config.Metadata.Full = true
 	config.Consumer.Fetch.Min = 1024 * 1024              // 1MB
 	config.Consumer.MaxWaitTime = 500 * time.Millisecond // 500ms
-	return config
+
+	if kafkaConfig.Version != "" {
+		version, err := sarama.ParseKafkaVersion(kafkaConfig.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kafka version: %w", err)
+		}
+		config.Version = version
+	}
+
+	switch consumerConfig.InitialOffset {
+	case "", "newest":
+		config.Consumer.Offsets.Initial = sarama.OffsetNewest
+	case "oldest":
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	default:
+		return nil, fmt.Errorf("invalid consumer initial offset: %s", consumerConfig.InitialOffset)
+	}
+
+	if consumerConfig.SessionTimeout > 0 {
+		config.Consumer.Group.Session.Timeout = consumerConfig.SessionTimeout
+	}
+	if consumerConfig.HeartbeatInterval > 0 {
+		config.Consumer.Group.Heartbeat.Interval = consumerConfig.HeartbeatInterval
+	}
+
+	if err := security.Apply(config, kafkaConfig); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func groupID(kafkaConfig configs.Kafka) string {
+	if kafkaConfig.Consumer.GroupID != "" {
+		return kafkaConfig.Consumer.GroupID
+	}
+
+	return kafkaConfig.ClientID
 }
 
 func NewConsumer(
 	kafkaConfig configs.Kafka,
+	tracerProvider trace.TracerProvider,
 	logger *zap.Logger,
 ) (Consumer, error) {
-	saramaConsumer, err := sarama.NewConsumerGroup(
-		kafkaConfig.Addresses,
-		kafkaConfig.ClientID,
-		newSaramaConsumerConfig(kafkaConfig),
-	)
+	saramaConfig, err := newSaramaConsumerConfig(kafkaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client is shared with the consumer group so that health checks see the connections it uses.
+	saramaClient, err := sarama.NewClient(kafkaConfig.Addresses, saramaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sarama client: %w", err)
+	}
+
+	saramaConsumer, err := sarama.NewConsumerGroupFromClient(groupID(kafkaConfig), saramaClient)
 	if err != nil {
+		saramaClient.Close()
 		return nil, fmt.Errorf("failed to create sarama consumer: %w", err)
 	}
 
 	return &consumer{
-		saramaConsumer:            saramaConsumer,
-		logger:                    logger,
-		queueNameToHandlerFuncMap: make(map[string]MessageHandlerFunc),
+		saramaClient:          saramaClient,
+		saramaConsumer:        saramaConsumer,
+		progress:              newProgressTracker(),
+		consumerConfig:        kafkaConfig.Consumer,
+		tracer:                tracerProvider.Tracer(tracerName),
+		logger:                logger,
+		queueNameToHandlerMap: make(map[string]*queueHandler),
 	}, nil
 }
 
-func (c consumer) RegisterHandler(queueName string, handlerFunc MessageHandlerFunc) {
-	c.queueNameToHandlerFuncMap[queueName] = handlerFunc
+func (c consumer) RegisterHandler(queueName string, handlerFunc MessageHandlerFunc, options ...HandlerOption) {
+	concurrency, ok := c.consumerConfig.TopicConcurrency[queueName]
+	if !ok {
+		concurrency = c.consumerConfig.Concurrency
+	}
+
+	retryMax := c.consumerConfig.RetryMax
+	if retryMax <= 0 {
+		retryMax = defaultHandleRetryMax
+	}
+
+	retryBackoff := c.consumerConfig.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultHandleRetryBackoff
+	}
+
+	handler := &queueHandler{
+		handlerFunc:  handlerFunc,
+		concurrency:  concurrency,
+		retryMax:     retryMax,
+		retryBackoff: retryBackoff,
+	}
+	for _, option := range options {
+		option(handler)
+	}
+
+	c.queueNameToHandlerMap[queueName] = handler
 }
 
 func (c consumer) Start(ctx context.Context) error {
 	logger := utils.LoggerWithContext(ctx, c.logger)
 
-	exitSignalChannel := make(chan os.Signal, 1)
-	signal.Notify(exitSignalChannel, os.Interrupt)
+	queueNameList := make([]string, 0, len(c.queueNameToHandlerMap))
+	for queueName := range c.queueNameToHandlerMap {
+		queueNameList = append(queueNameList, queueName)
+	}
 
 	fmt.Println("notification_service kafka consumer started")
 	logger.Info("notification_service kafka consumer started")
-	for queueName, handlerFunc := range c.queueNameToHandlerFuncMap {
-		go func(queueName string, handlerFunc MessageHandlerFunc) {
-			if err := c.saramaConsumer.Consume(
-				context.Background(),
-				[]string{queueName},
-				newConsumerHandler(handlerFunc, exitSignalChannel),
-			); err != nil {
-				logger.
-					With(zap.String("queue_name", queueName)).
-					With(zap.Error(err)).
-					Error("failed to consume message from queue")
+
+	handler := newConsumerHandler(c.queueNameToHandlerMap, c.progress, c.tracer, c.logger)
+	for {
+		// Consume returns whenever the session ends, e.g. on rebalance, so it has to be called in a loop.
+		if err := c.saramaConsumer.Consume(ctx, queueNameList, handler); err != nil {
+			logger.
+				With(zap.Strings("queue_name_list", queueNameList)).
+				With(zap.Error(err)).
+				Error("failed to consume message from queue")
+
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(consumeRetryDelay):
 			}
-		}(queueName, handlerFunc)
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
 	}
 
-	<-exitSignalChannel
-	return nil
+	logger.Info("notification_service kafka consumer stopping")
+	if err := c.saramaConsumer.Close(); err != nil {
+		return err
+	}
+
+	// A consumer group created from a client does not close it.
+	return c.saramaClient.Close()
+}
+
+func (c consumer) Ping(ctx context.Context) error {
+	// RefreshMetadata cannot be cancelled, it is left running in the background if ctx is done first.
+	refreshed := make(chan error, 1)
+	go func() {
+		refreshed <- c.saramaClient.RefreshMetadata()
+	}()
+
+	select {
+	case err := <-refreshed:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c consumer) IsMember() bool {
+	return c.progress.IsMember()
+}
+
+func (c consumer) CheckProgress(threshold time.Duration) error {
+	return c.progress.Check(threshold)
 }
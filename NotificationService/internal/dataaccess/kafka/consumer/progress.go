@@ -0,0 +1,52 @@
+package consumer
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// progressTracker records the progress of the consumer, so that a consumer stuck on its messages can be told
+// apart from an idle one. Times are in unix nanoseconds.
+type progressTracker struct {
+	member        atomic.Bool
+	inFlight      atomic.Int64
+	lastHandledAt atomic.Int64
+}
+
+func newProgressTracker() *progressTracker {
+	return &progressTracker{}
+}
+
+func (t *progressTracker) SetMember(member bool) {
+	t.member.Store(member)
+}
+
+func (t *progressTracker) IsMember() bool {
+	return t.member.Load()
+}
+
+// Dispatched records a message handed over to a worker. The clock of a consumer that was idle starts with it.
+func (t *progressTracker) Dispatched() {
+	if t.inFlight.Add(1) == 1 {
+		t.lastHandledAt.Store(time.Now().UnixNano())
+	}
+}
+
+// Handled records a dispatched message whose handling finished, whatever its outcome.
+func (t *progressTracker) Handled() {
+	t.lastHandledAt.Store(time.Now().UnixNano())
+	t.inFlight.Add(-1)
+}
+
+// Check returns an error if the consumer has had messages in flight without finishing any of them for longer
+// than threshold. Being out of the consumer group is not a stall: it is what an outage of Kafka looks like.
+func (t *progressTracker) Check(threshold time.Duration) error {
+	if inFlight := t.inFlight.Load(); inFlight > 0 {
+		if stalledFor := time.Since(time.Unix(0, t.lastHandledAt.Load())); stalledFor > threshold {
+			return fmt.Errorf("consumer has %d messages in flight but handled none for %s", inFlight, stalledFor.Round(time.Second))
+		}
+	}
+
+	return nil
+}
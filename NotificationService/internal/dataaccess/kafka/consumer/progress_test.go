@@ -0,0 +1,77 @@
+package consumer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressTrackerCheck(t *testing.T) {
+	const threshold = time.Minute
+
+	testCases := []struct {
+		name          string
+		member        bool
+		inFlight      int64
+		lastHandledAt time.Duration
+		wantErr       bool
+	}{
+		{
+			name:          "idle member",
+			member:        true,
+			lastHandledAt: -time.Hour,
+		},
+		{
+			name:          "member handling messages",
+			member:        true,
+			inFlight:      3,
+			lastHandledAt: -time.Second,
+		},
+		{
+			name:          "member stuck on its messages",
+			member:        true,
+			inFlight:      3,
+			lastHandledAt: -2 * threshold,
+			wantErr:       true,
+		},
+		{
+			name:          "out of the consumer group for long",
+			lastHandledAt: -2 * threshold,
+		},
+		{
+			name:          "out of the consumer group and stuck on its messages",
+			inFlight:      1,
+			lastHandledAt: -2 * threshold,
+			wantErr:       true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			now := time.Now()
+			tracker := newProgressTracker()
+			tracker.member.Store(testCase.member)
+			tracker.inFlight.Store(testCase.inFlight)
+			tracker.lastHandledAt.Store(now.Add(testCase.lastHandledAt).UnixNano())
+
+			if err := tracker.Check(threshold); (err != nil) != testCase.wantErr {
+				t.Errorf("Check() error = %v, want error %t", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestProgressTrackerDispatchedStartsTheClockOfAnIdleConsumer(t *testing.T) {
+	tracker := newProgressTracker()
+	tracker.SetMember(true)
+	tracker.lastHandledAt.Store(time.Now().Add(-time.Hour).UnixNano())
+
+	tracker.Dispatched()
+	if err := tracker.Check(time.Minute); err != nil {
+		t.Errorf("Check() error = %v after dispatching to an idle consumer, want nil", err)
+	}
+
+	tracker.Handled()
+	if inFlight := tracker.inFlight.Load(); inFlight != 0 {
+		t.Errorf("%d messages in flight after handling the dispatched one, want 0", inFlight)
+	}
+}
@@ -0,0 +1,309 @@
+package consumer
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/utils"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ReplayRange bounds the messages of every partition that are replayed. Offsets are inclusive and ignored
+// when negative, times are ignored when zero. An unset lower bound starts at the oldest retained message,
+// an unset upper bound stops at the newest message present when the replay starts.
+type ReplayRange struct {
+	FromOffset int64
+	ToOffset   int64
+	FromTime   time.Time
+	ToTime     time.Time
+}
+
+type Replayer interface {
+	// Replay reads the messages of queueName within replayRange with a throwaway consumer group and hands
+	// them to handlerFunc in order per partition. It returns once every partition reached the end of the range.
+	Replay(ctx context.Context, queueName string, replayRange ReplayRange, handlerFunc MessageHandlerFunc) error
+}
+
+type replayer struct {
+	kafkaConfig configs.Kafka
+	tracer      trace.Tracer
+	logger      *zap.Logger
+}
+
+func NewReplayer(
+	kafkaConfig configs.Kafka,
+	tracerProvider trace.TracerProvider,
+	logger *zap.Logger,
+) Replayer {
+	return &replayer{
+		kafkaConfig: kafkaConfig,
+		tracer:      tracerProvider.Tracer(tracerName),
+		logger:      logger,
+	}
+}
+
+func (r replayer) Replay(
+	ctx context.Context,
+	queueName string,
+	replayRange ReplayRange,
+	handlerFunc MessageHandlerFunc,
+) error {
+	logger := utils.LoggerWithContext(ctx, r.logger).With(zap.String(utils.LogFieldQueueName, queueName))
+
+	saramaConfig, err := newSaramaConsumerConfig(r.kafkaConfig)
+	if err != nil {
+		return err
+	}
+	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+	saramaConfig.Consumer.Offsets.AutoCommit.Enable = false
+
+	client, err := sarama.NewClient(r.kafkaConfig.Addresses, saramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create sarama client: %w", err)
+	}
+	defer client.Close()
+
+	partitionRanges, err := resolvePartitionRanges(client, queueName, replayRange)
+	if err != nil {
+		return err
+	}
+	if len(partitionRanges) == 0 {
+		logger.Info("no message in replay range")
+		return nil
+	}
+
+	replayGroupID := fmt.Sprintf("%s-replay-%d", groupID(r.kafkaConfig), time.Now().UnixNano())
+	consumerGroup, err := sarama.NewConsumerGroupFromClient(replayGroupID, client)
+	if err != nil {
+		return fmt.Errorf("failed to create sarama consumer: %w", err)
+	}
+	defer consumerGroup.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger.With(zap.String("group_id", replayGroupID)).Info("replay started")
+
+	handler := newReplayHandler(partitionRanges, handlerFunc, cancel, r.tracer, logger)
+	for ctx.Err() == nil {
+		if err := consumerGroup.Consume(ctx, []string{queueName}, handler); err != nil {
+			return fmt.Errorf("failed to consume message from queue: %w", err)
+		}
+	}
+
+	if !handler.Finished() {
+		return ctx.Err()
+	}
+
+	logger.Info("replay finished")
+	return nil
+}
+
+// offsetRange is the half-open range [start, end) of offsets replayed in a partition.
+type offsetRange struct {
+	start int64
+	end   int64
+}
+
+func resolvePartitionRanges(
+	client sarama.Client,
+	queueName string,
+	replayRange ReplayRange,
+) (map[int32]offsetRange, error) {
+	partitions, err := client.Partitions(queueName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions of queue %s: %w", queueName, err)
+	}
+
+	partitionRanges := make(map[int32]offsetRange)
+	for _, partition := range partitions {
+		oldest, err := client.GetOffset(queueName, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+
+		newest, err := client.GetOffset(queueName, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+
+		partitionRange := offsetRange{start: oldest, end: newest}
+
+		if replayRange.FromOffset >= 0 {
+			partitionRange.start = max(partitionRange.start, replayRange.FromOffset)
+		}
+		if !replayRange.FromTime.IsZero() {
+			offset, err := offsetForTime(client, queueName, partition, replayRange.FromTime, newest)
+			if err != nil {
+				return nil, err
+			}
+			partitionRange.start = max(partitionRange.start, offset)
+		}
+
+		if replayRange.ToOffset >= 0 {
+			partitionRange.end = min(partitionRange.end, replayRange.ToOffset+1)
+		}
+		if !replayRange.ToTime.IsZero() {
+			offset, err := offsetForTime(client, queueName, partition, replayRange.ToTime, newest)
+			if err != nil {
+				return nil, err
+			}
+			partitionRange.end = min(partitionRange.end, offset)
+		}
+
+		if partitionRange.start < partitionRange.end {
+			partitionRanges[partition] = partitionRange
+		}
+	}
+
+	return partitionRanges, nil
+}
+
+// offsetForTime returns the offset of the first message produced at or after t, or newest if there is none.
+func offsetForTime(client sarama.Client, queueName string, partition int32, t time.Time, newest int64) (int64, error) {
+	offset, err := client.GetOffset(queueName, partition, t.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		return newest, nil
+	}
+
+	return offset, nil
+}
+
+// replayHandler handles the messages of each partition sequentially, and cancels the replay once every
+// partition has reached the end of its range. Progress survives rebalances, messages are never replayed twice.
+type replayHandler struct {
+	mu              sync.Mutex
+	partitionRanges map[int32]offsetRange
+	finished        map[int32]bool
+	handlerFunc     MessageHandlerFunc
+	cancel          context.CancelFunc
+	tracer          trace.Tracer
+	logger          *zap.Logger
+}
+
+func newReplayHandler(
+	partitionRanges map[int32]offsetRange,
+	handlerFunc MessageHandlerFunc,
+	cancel context.CancelFunc,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *replayHandler {
+	return &replayHandler{
+		partitionRanges: partitionRanges,
+		finished:        make(map[int32]bool),
+		handlerFunc:     handlerFunc,
+		cancel:          cancel,
+		tracer:          tracer,
+		logger:          logger,
+	}
+}
+
+func (h *replayHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for queueName, partitions := range session.Claims() {
+		for _, partition := range partitions {
+			partitionRange, ok := h.partitionRanges[partition]
+			if !ok {
+				continue
+			}
+
+			// The throwaway group has no committed offset: ResetOffset moves it backwards and MarkOffset
+			// forwards, whichever applies, so that the claim starts at the beginning of the range.
+			session.ResetOffset(queueName, partition, partitionRange.start, "")
+			session.MarkOffset(queueName, partition, partitionRange.start, "")
+		}
+	}
+
+	return nil
+}
+
+func (h *replayHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *replayHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	partitionRange, ok := h.partitionRange(claim.Partition())
+	if !ok {
+		return nil
+	}
+
+	ctx := context.WithoutCancel(session.Context())
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			if message.Offset >= partitionRange.start && message.Offset < partitionRange.end {
+				messageCtx, span := startMessageSpan(contextWithRequestID(ctx, message), h.tracer, message)
+				err := h.handlerFunc(messageCtx, message.Topic, message.Value)
+				if err != nil {
+					utils.LoggerWithContext(messageCtx, h.logger).
+						With(zap.Int32("partition", message.Partition)).
+						With(zap.Int64("offset", message.Offset)).
+						With(zap.Error(err)).
+						Error("failed to replay message")
+				}
+				utils.EndSpan(span, err)
+				h.advance(message.Partition, message.Offset+1)
+			}
+
+			if message.Offset+1 >= partitionRange.end {
+				h.finish(message.Partition)
+				return nil
+			}
+
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (h *replayHandler) partitionRange(partition int32) (offsetRange, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.finished[partition] {
+		return offsetRange{}, false
+	}
+
+	partitionRange, ok := h.partitionRanges[partition]
+	return partitionRange, ok
+}
+
+func (h *replayHandler) advance(partition int32, nextOffset int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	partitionRange := h.partitionRanges[partition]
+	partitionRange.start = nextOffset
+	h.partitionRanges[partition] = partitionRange
+}
+
+func (h *replayHandler) finish(partition int32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.finished[partition] = true
+	if len(h.finished) == len(h.partitionRanges) {
+		h.cancel()
+	}
+}
+
+func (h *replayHandler) Finished() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.finished) == len(h.partitionRanges)
+}
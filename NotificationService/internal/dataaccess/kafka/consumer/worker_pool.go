@@ -0,0 +1,256 @@
+package consumer
+
+import (
+	"NotificationService/internal/dataaccess/kafka/tracing"
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// offsetTracker tracks the offsets of a partition that are being handled, so that only the highest offset
+// below which every message has been handled gets committed, even if messages complete out of order.
+type offsetTracker struct {
+	mu       sync.Mutex
+	inFlight []int64
+	done     map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		done: make(map[int64]bool),
+	}
+}
+
+func (t *offsetTracker) Add(offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight = append(t.inFlight, offset)
+}
+
+// Complete marks offset as handled and returns the highest contiguous completed offset, or false if the
+// oldest in-flight offset is still being handled.
+func (t *offsetTracker) Complete(offset int64) (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.done[offset] = true
+
+	var (
+		committable int64
+		ok          bool
+	)
+	for len(t.inFlight) > 0 && t.done[t.inFlight[0]] {
+		committable, ok = t.inFlight[0], true
+		delete(t.done, t.inFlight[0])
+		t.inFlight = t.inFlight[1:]
+	}
+
+	return committable, ok
+}
+
+// partitionWorkerPool handles the messages of one partition with a bounded number of workers. Messages with
+// the same key are always handled by the same worker, so they are processed in the order they were produced.
+//
+// A message whose handling fails is retried with an exponential backoff, unless the failure is permanent, and
+// then parked with the dead letter func of its handler. Its offset is only completed once it was handled or
+// parked, so a message that could be neither holds back the committed offset of the partition and is consumed
+// again by the next session. Its worker then stops handling messages for the rest of the session, so that the
+// later messages with the same key are not handled before it.
+type partitionWorkerPool struct {
+	session       sarama.ConsumerGroupSession
+	handler       *queueHandler
+	progress      *progressTracker
+	offsetTracker *offsetTracker
+	workerQueues  []chan *sarama.ConsumerMessage
+	wg            sync.WaitGroup
+	tracer        trace.Tracer
+	logger        *zap.Logger
+}
+
+func newPartitionWorkerPool(
+	session sarama.ConsumerGroupSession,
+	handler *queueHandler,
+	progress *progressTracker,
+	tracer trace.Tracer,
+	logger *zap.Logger,
+) *partitionWorkerPool {
+	concurrency := handler.concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	pool := &partitionWorkerPool{
+		session:       session,
+		handler:       handler,
+		progress:      progress,
+		offsetTracker: newOffsetTracker(),
+		workerQueues:  make([]chan *sarama.ConsumerMessage, concurrency),
+		tracer:        tracer,
+		logger:        logger,
+	}
+
+	// Handlers receive a context that is not cancelled together with the session, so that in-flight work
+	// is drained when the consumer stops.
+	ctx := context.WithoutCancel(session.Context())
+	for i := range pool.workerQueues {
+		pool.workerQueues[i] = make(chan *sarama.ConsumerMessage)
+		pool.wg.Add(1)
+		go pool.work(ctx, pool.workerQueues[i])
+	}
+
+	return pool
+}
+
+// Dispatch hands message over to the worker owning its key, blocking while that worker is busy.
+func (p *partitionWorkerPool) Dispatch(message *sarama.ConsumerMessage) {
+	p.offsetTracker.Add(message.Offset)
+	p.progress.Dispatched()
+	p.workerQueues[p.workerIndex(message)] <- message
+}
+
+// Stop waits for the dispatched messages to be handled.
+func (p *partitionWorkerPool) Stop() {
+	for _, workerQueue := range p.workerQueues {
+		close(workerQueue)
+	}
+	p.wg.Wait()
+}
+
+func (p *partitionWorkerPool) work(ctx context.Context, workerQueue <-chan *sarama.ConsumerMessage) {
+	defer p.wg.Done()
+
+	blocked := false
+	for message := range workerQueue {
+		if blocked {
+			// Left uncommitted behind the failed message, it is consumed again by the next session.
+			p.progress.Handled()
+			continue
+		}
+
+		messageCtx, span := startMessageSpan(contextWithRequestID(ctx, message), p.tracer, message)
+		logger := utils.LoggerWithContext(messageCtx, p.logger).
+			With(zap.String(utils.LogFieldQueueName, message.Topic)).
+			With(zap.Int32("partition", message.Partition)).
+			With(zap.Int64("offset", message.Offset))
+
+		err := p.handleWithRetry(messageCtx, logger, message)
+		if err != nil && p.session.Context().Err() == nil {
+			err = p.park(messageCtx, logger, message, err)
+		}
+		utils.EndSpan(span, err)
+		p.progress.Handled()
+
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to handle message, leaving its offset uncommitted until the next session")
+			blocked = true
+			continue
+		}
+
+		if offset, ok := p.offsetTracker.Complete(message.Offset); ok {
+			p.session.MarkOffset(message.Topic, message.Partition, offset+1, "")
+		}
+	}
+}
+
+// handleWithRetry handles message, retrying a failure with an exponential backoff. The retries stop early on a
+// permanent failure, and when the session ends since the message is then consumed again by the next session.
+func (p *partitionWorkerPool) handleWithRetry(
+	ctx context.Context,
+	logger *zap.Logger,
+	message *sarama.ConsumerMessage,
+) error {
+	backoff := p.handler.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := p.handler.handlerFunc(ctx, message.Topic, message.Value)
+		if err == nil || IsPermanent(err) || attempt > p.handler.retryMax {
+			return err
+		}
+
+		logger.With(zap.Error(err)).With(zap.Int("attempt", attempt)).Warn("failed to handle message, retrying")
+		select {
+		case <-p.session.Context().Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, maxHandleRetryBackoff)
+	}
+}
+
+// park hands message, whose handling failed with reason, over to the dead letter func of its handler. It returns
+// an error if the message could not be parked, in which case its offset must not be completed.
+func (p *partitionWorkerPool) park(
+	ctx context.Context,
+	logger *zap.Logger,
+	message *sarama.ConsumerMessage,
+	reason error,
+) error {
+	if p.handler.deadLetterFunc == nil {
+		return reason
+	}
+
+	if err := p.handler.deadLetterFunc(ctx, message.Topic, string(message.Key), message.Value, reason); err != nil {
+		logger.With(zap.Error(err)).Error("failed to park message on dead letter topic")
+		return fmt.Errorf("failed to park message: %w", errors.Join(reason, err))
+	}
+
+	logger.With(zap.NamedError("reason", reason)).Warn("parked message on dead letter topic")
+	return nil
+}
+
+func (p *partitionWorkerPool) workerIndex(message *sarama.ConsumerMessage) int {
+	key := string(message.Key)
+	if key == "" {
+		// Messages without a key have no ordering requirement.
+		return int(message.Offset % int64(len(p.workerQueues)))
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(len(p.workerQueues)))
+}
+
+const (
+	headerRequestID = "request_id"
+)
+
+// contextWithRequestID carries the request id of message, or a new one if it was produced without one, so
+// that the log lines of its handling can be correlated.
+func contextWithRequestID(ctx context.Context, message *sarama.ConsumerMessage) context.Context {
+	requestID := tracing.ConsumerMessageCarrier{Message: message}.Get(headerRequestID)
+	if requestID == "" {
+		requestID = utils.NewRequestID()
+	}
+
+	return utils.ContextWithRequestID(ctx, requestID)
+}
+
+// startMessageSpan continues the trace carried in the headers of message with a span covering its handling,
+// so that the calls and messages made while handling it belong to the trace of its producer.
+func startMessageSpan(ctx context.Context, tracer trace.Tracer, message *sarama.ConsumerMessage) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, tracing.ConsumerMessageCarrier{Message: message})
+
+	return tracer.Start(
+		ctx,
+		fmt.Sprintf("%s process", message.Topic),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationDeliver,
+			semconv.MessagingDestinationName(message.Topic),
+			semconv.MessagingKafkaDestinationPartition(int(message.Partition)),
+			semconv.MessagingKafkaMessageOffset(int(message.Offset)),
+		),
+	)
+}
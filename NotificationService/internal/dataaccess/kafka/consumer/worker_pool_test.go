@@ -0,0 +1,187 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func TestOffsetTracker(t *testing.T) {
+	type completion struct {
+		offset          int64
+		wantCommittable int64
+		wantOk          bool
+	}
+
+	testCases := []struct {
+		name        string
+		offsets     []int64
+		completions []completion
+	}{
+		{
+			name:    "completed in order",
+			offsets: []int64{10, 11, 12},
+			completions: []completion{
+				{offset: 10, wantCommittable: 10, wantOk: true},
+				{offset: 11, wantCommittable: 11, wantOk: true},
+				{offset: 12, wantCommittable: 12, wantOk: true},
+			},
+		},
+		{
+			name:    "completed in reverse order",
+			offsets: []int64{10, 11, 12},
+			completions: []completion{
+				{offset: 12},
+				{offset: 11},
+				{offset: 10, wantCommittable: 12, wantOk: true},
+			},
+		},
+		{
+			name:    "oldest offset holds back the later ones",
+			offsets: []int64{10, 11, 12, 13},
+			completions: []completion{
+				{offset: 11},
+				{offset: 13},
+				{offset: 10, wantCommittable: 11, wantOk: true},
+				{offset: 12, wantCommittable: 13, wantOk: true},
+			},
+		},
+		{
+			name:    "gaps between offsets",
+			offsets: []int64{5, 9, 20},
+			completions: []completion{
+				{offset: 9},
+				{offset: 5, wantCommittable: 9, wantOk: true},
+				{offset: 20, wantCommittable: 20, wantOk: true},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tracker := newOffsetTracker()
+			for _, offset := range testCase.offsets {
+				tracker.Add(offset)
+			}
+
+			for _, completion := range testCase.completions {
+				committable, ok := tracker.Complete(completion.offset)
+				if ok != completion.wantOk || (ok && committable != completion.wantCommittable) {
+					t.Errorf(
+						"Complete(%d) = %d, %t, want %d, %t",
+						completion.offset, committable, ok, completion.wantCommittable, completion.wantOk,
+					)
+				}
+			}
+
+			if len(tracker.inFlight) != 0 || len(tracker.done) != 0 {
+				t.Errorf("tracker still holds offsets %v in flight and %v done", tracker.inFlight, tracker.done)
+			}
+		})
+	}
+}
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+
+	mu            sync.Mutex
+	markedOffsets []int64
+}
+
+func (s *fakeSession) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeSession) MarkOffset(_ string, _ int32, offset int64, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.markedOffsets = append(s.markedOffsets, offset)
+}
+
+func TestPartitionWorkerPool(t *testing.T) {
+	errHandle := errors.New("handle failed")
+
+	testCases := []struct {
+		name              string
+		handleErrs        map[string]error
+		deadLetter        bool
+		wantHandled       []string
+		wantParked        []string
+		wantMarkedOffsets []int64
+	}{
+		{
+			name:              "permanent failure is parked without retry",
+			handleErrs:        map[string]error{"0": Permanent(errHandle)},
+			deadLetter:        true,
+			wantHandled:       []string{"0", "1"},
+			wantParked:        []string{"0"},
+			wantMarkedOffsets: []int64{1, 2},
+		},
+		{
+			name:              "failure is retried before being parked",
+			handleErrs:        map[string]error{"0": errHandle},
+			deadLetter:        true,
+			wantHandled:       []string{"0", "0", "0", "1"},
+			wantParked:        []string{"0"},
+			wantMarkedOffsets: []int64{1, 2},
+		},
+		{
+			name:        "message that cannot be parked holds back the later messages",
+			handleErrs:  map[string]error{"0": Permanent(errHandle)},
+			wantHandled: []string{"0"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				handled []string
+				parked  []string
+			)
+			handler := &queueHandler{
+				handlerFunc: func(_ context.Context, _ string, payload []byte) error {
+					mu.Lock()
+					defer mu.Unlock()
+
+					handled = append(handled, string(payload))
+					return testCase.handleErrs[string(payload)]
+				},
+				concurrency:  1,
+				retryMax:     2,
+				retryBackoff: time.Millisecond,
+			}
+			if testCase.deadLetter {
+				handler.deadLetterFunc = func(_ context.Context, _ string, _ string, payload []byte, _ error) error {
+					parked = append(parked, string(payload))
+					return nil
+				}
+			}
+
+			session := &fakeSession{}
+			pool := newPartitionWorkerPool(session, handler, newProgressTracker(), noop.NewTracerProvider().Tracer(""), zap.NewNop())
+			for offset, payload := range []string{"0", "1"} {
+				pool.Dispatch(&sarama.ConsumerMessage{Key: []byte("key"), Value: []byte(payload), Offset: int64(offset)})
+			}
+			pool.Stop()
+
+			if !slices.Equal(handled, testCase.wantHandled) {
+				t.Errorf("handled %v, want %v", handled, testCase.wantHandled)
+			}
+			if !slices.Equal(parked, testCase.wantParked) {
+				t.Errorf("parked %v, want %v", parked, testCase.wantParked)
+			}
+			if !slices.Equal(session.markedOffsets, testCase.wantMarkedOffsets) {
+				t.Errorf("marked offsets %v, want %v", session.markedOffsets, testCase.wantMarkedOffsets)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package envelope
+
+import (
+	"NotificationService/internal/generated/event"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	SpecVersion = "1.0"
+	Source      = "notification_service"
+
+	ContentTypeJSON     = "application/cloudevents+json"
+	ContentTypeProtobuf = "application/cloudevents+protobuf"
+	DataContentTypeJSON = "application/json"
+
+	// LegacySchemaVersion is the schema version assumed for payloads published without an envelope.
+	LegacySchemaVersion = 1
+)
+
+type Encoding string
+
+const (
+	EncodingJSON     Encoding = "json"
+	EncodingProtobuf Encoding = "protobuf"
+)
+
+var (
+	ErrInvalidEnvelope          = errors.New("invalid event envelope")
+	ErrInvalidEvent             = errors.New("invalid event")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
+)
+
+// Envelope carries the metadata shared by every event, Data holds the JSON encoded event itself.
+type Envelope struct {
+	ID              string
+	Type            string
+	Source          string
+	Time            time.Time
+	SchemaVersion   uint32
+	DataContentType string
+	Data            []byte
+	// Legacy is set when the payload was published as a bare JSON event without an envelope.
+	Legacy bool
+}
+
+// jsonEnvelope is the CloudEvents structured mode representation of an envelope.
+type jsonEnvelope struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	Time            time.Time       `json:"time"`
+	SchemaVersion   uint32          `json:"schemaversion"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+func New(eventType string, schemaVersion uint32, data any) (Envelope, error) {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return Envelope{}, fmt.Errorf("failed to generate event id: %w", err)
+	}
+
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	return Envelope{
+		ID:              id,
+		Type:            eventType,
+		Source:          Source,
+		Time:            time.Now().UTC(),
+		SchemaVersion:   schemaVersion,
+		DataContentType: DataContentTypeJSON,
+		Data:            dataBytes,
+	}, nil
+}
+
+func (e Envelope) Validate() error {
+	switch {
+	case e.Legacy:
+		return nil
+	case e.ID == "":
+		return fmt.Errorf("%w: missing id", ErrInvalidEnvelope)
+	case e.Type == "":
+		return fmt.Errorf("%w: missing type", ErrInvalidEnvelope)
+	case e.Source == "":
+		return fmt.Errorf("%w: missing source", ErrInvalidEnvelope)
+	case e.SchemaVersion == 0:
+		return fmt.Errorf("%w: missing schema version", ErrInvalidEnvelope)
+	case e.DataContentType != DataContentTypeJSON:
+		return fmt.Errorf("%w: unsupported data content type %q", ErrInvalidEnvelope, e.DataContentType)
+	}
+
+	return nil
+}
+
+// ContentType returns the content type of envelopes marshaled with encoding.
+func ContentType(encoding Encoding) string {
+	if encoding == EncodingProtobuf {
+		return ContentTypeProtobuf
+	}
+
+	return ContentTypeJSON
+}
+
+func Marshal(envelope Envelope, encoding Encoding) ([]byte, error) {
+	switch encoding {
+	case EncodingProtobuf:
+		return proto.Marshal(&event.Envelope{
+			Id:              envelope.ID,
+			SpecVersion:     SpecVersion,
+			Type:            envelope.Type,
+			Source:          envelope.Source,
+			Time:            timestamppb.New(envelope.Time),
+			SchemaVersion:   envelope.SchemaVersion,
+			DataContentType: envelope.DataContentType,
+			Data:            envelope.Data,
+		})
+	case EncodingJSON, "":
+		return json.Marshal(jsonEnvelope{
+			SpecVersion:     SpecVersion,
+			ID:              envelope.ID,
+			Type:            envelope.Type,
+			Source:          envelope.Source,
+			Time:            envelope.Time,
+			SchemaVersion:   envelope.SchemaVersion,
+			DataContentType: envelope.DataContentType,
+			Data:            envelope.Data,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported envelope encoding %q", encoding)
+	}
+}
+
+// Unmarshal decodes an envelope from either of its encodings. JSON objects without a specversion attribute
+// are treated as legacy events, the whole payload becomes the data of the returned envelope.
+func Unmarshal(payload []byte) (Envelope, error) {
+	trimmedPayload := bytes.TrimSpace(payload)
+	if len(trimmedPayload) == 0 {
+		return Envelope{}, fmt.Errorf("%w: empty payload", ErrInvalidEnvelope)
+	}
+
+	// A JSON object always starts with '{', which is never a valid first byte of a protobuf envelope.
+	if trimmedPayload[0] != '{' {
+		return unmarshalProtobuf(payload)
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(trimmedPayload, &attributes); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+
+	if _, ok := attributes["specversion"]; !ok {
+		return Envelope{
+			SchemaVersion:   LegacySchemaVersion,
+			DataContentType: DataContentTypeJSON,
+			Data:            trimmedPayload,
+			Legacy:          true,
+		}, nil
+	}
+
+	var decoded jsonEnvelope
+	if err := json.Unmarshal(trimmedPayload, &decoded); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+
+	if decoded.SpecVersion != SpecVersion {
+		return Envelope{}, fmt.Errorf("%w: unsupported spec version %q", ErrInvalidEnvelope, decoded.SpecVersion)
+	}
+
+	envelope := Envelope{
+		ID:              decoded.ID,
+		Type:            decoded.Type,
+		Source:          decoded.Source,
+		Time:            decoded.Time,
+		SchemaVersion:   decoded.SchemaVersion,
+		DataContentType: decoded.DataContentType,
+		Data:            decoded.Data,
+	}
+
+	return envelope, envelope.Validate()
+}
+
+func unmarshalProtobuf(payload []byte) (Envelope, error) {
+	var decoded event.Envelope
+	if err := proto.Unmarshal(payload, &decoded); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+
+	if err := decoded.ValidateAll(); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+
+	if decoded.GetSpecVersion() != SpecVersion {
+		return Envelope{}, fmt.Errorf("%w: unsupported spec version %q", ErrInvalidEnvelope, decoded.GetSpecVersion())
+	}
+
+	envelope := Envelope{
+		ID:              decoded.GetId(),
+		Type:            decoded.GetType(),
+		Source:          decoded.GetSource(),
+		Time:            decoded.GetTime().AsTime(),
+		SchemaVersion:   decoded.GetSchemaVersion(),
+		DataContentType: decoded.GetDataContentType(),
+		Data:            decoded.GetData(),
+	}
+
+	return envelope, envelope.Validate()
+}
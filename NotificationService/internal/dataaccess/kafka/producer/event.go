@@ -0,0 +1,36 @@
+package producer
+
+import (
+	"NotificationService/internal/dataaccess/kafka/envelope"
+	"strconv"
+)
+
+// newEventMessage wraps event into an envelope of the given type and schema version and encodes it
+// into a message keyed by key.
+func newEventMessage(
+	key string,
+	eventType string,
+	schemaVersion uint32,
+	event any,
+	encoding envelope.Encoding,
+) (Message, error) {
+	eventEnvelope, err := envelope.New(eventType, schemaVersion, event)
+	if err != nil {
+		return Message{}, err
+	}
+
+	payload, err := envelope.Marshal(eventEnvelope, encoding)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Key: key,
+		Headers: map[string]string{
+			HeaderContentType:   envelope.ContentType(encoding),
+			HeaderEventType:     eventType,
+			HeaderSchemaVersion: strconv.FormatUint(uint64(schemaVersion), 10),
+		},
+		Payload: payload,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package producer
+
+import (
+	"NotificationService/internal/utils"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+const (
+	TopicNameNotificationServiceEventDeadLetter = "notification_service_event_dead_letter"
+
+	HeaderFailureReason = "failure_reason"
+)
+
+// EventDeadLetterProducer publishes events whose handling kept failing after being retried, unchanged, to the
+// dead letter topic so they can be inspected and replayed once the cause is fixed instead of being dropped.
+type EventDeadLetterProducer interface {
+	Produce(ctx context.Context, originalQueueName string, key string, payload []byte, reason error) error
+}
+
+func NewEventDeadLetterProducer(
+	producer Producer,
+	logger *zap.Logger,
+) EventDeadLetterProducer {
+	return &eventDeadLetter{
+		producer: producer,
+		logger:   logger,
+	}
+}
+
+type eventDeadLetter struct {
+	producer Producer
+	logger   *zap.Logger
+}
+
+func (e eventDeadLetter) Produce(
+	ctx context.Context,
+	originalQueueName string,
+	key string,
+	payload []byte,
+	reason error,
+) error {
+	logger := utils.LoggerWithContext(ctx, e.logger).
+		With(zap.String("original_queue_name", originalQueueName)).
+		With(zap.NamedError("reason", reason))
+	logger.Warn("parking failed event on dead letter topic")
+
+	err := e.producer.Produce(ctx, TopicNameNotificationServiceEventDeadLetter, Message{
+		Key: key,
+		Headers: map[string]string{
+			HeaderOriginalTopic: originalQueueName,
+			HeaderFailureReason: reason.Error(),
+		},
+		Payload: payload,
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to produce dead letter event")
+		return err
+	}
+
+	return nil
+}
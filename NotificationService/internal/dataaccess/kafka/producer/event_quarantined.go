@@ -0,0 +1,64 @@
+package producer
+
+import (
+	"NotificationService/internal/utils"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+const (
+	TopicNameNotificationServiceEventQuarantine = "notification_service_event_quarantine"
+
+	HeaderOriginalTopic   = "original_topic"
+	HeaderRejectionReason = "rejection_reason"
+)
+
+// EventQuarantineProducer publishes events that could not be decoded or failed validation, unchanged,
+// to the quarantine topic so they can be inspected and replayed instead of being dropped.
+type EventQuarantineProducer interface {
+	Produce(ctx context.Context, originalQueueName string, key string, payload []byte, reason error) error
+}
+
+func NewEventQuarantineProducer(
+	producer Producer,
+	logger *zap.Logger,
+) EventQuarantineProducer {
+	return &eventQuarantine{
+		producer: producer,
+		logger:   logger,
+	}
+}
+
+type eventQuarantine struct {
+	producer Producer
+	logger   *zap.Logger
+}
+
+func (e eventQuarantine) Produce(
+	ctx context.Context,
+	originalQueueName string,
+	key string,
+	payload []byte,
+	reason error,
+) error {
+	logger := utils.LoggerWithContext(ctx, e.logger).
+		With(zap.String("original_queue_name", originalQueueName)).
+		With(zap.NamedError("reason", reason))
+	logger.Warn("quarantining invalid event")
+
+	err := e.producer.Produce(ctx, TopicNameNotificationServiceEventQuarantine, Message{
+		Key: key,
+		Headers: map[string]string{
+			HeaderOriginalTopic:   originalQueueName,
+			HeaderRejectionReason: reason.Error(),
+		},
+		Payload: payload,
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to produce quarantined event")
+		return err
+	}
+
+	return nil
+}
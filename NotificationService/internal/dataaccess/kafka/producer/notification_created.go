@@ -1,19 +1,35 @@
 package producer
 
 import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/kafka/envelope"
 	"NotificationService/internal/utils"
 	"context"
-	"encoding/json"
+	"fmt"
 
 	"go.uber.org/zap"
 )
 
 const (
 	TopicNameNotificationServiceNotificationCreated = "notification_service_notification_created"
+
+	EventTypeNotificationCreated     = "notification_service.notification_created"
+	SchemaVersionNotificationCreated = 2
 )
 
+// NotificationCreated is produced once a notification is ready to be sent. Version 1 events carried the
+// booking id in ID, from version 2 on ID is the notification id and the booking id is in OfBookingId.
 type NotificationCreated struct {
-	ID uint32 `json:"id"`
+	ID          uint32 `json:"id"`
+	OfBookingId uint32 `json:"of_booking_id"`
+}
+
+func (n NotificationCreated) Validate() error {
+	if n.OfBookingId == 0 {
+		return fmt.Errorf("%w: missing of_booking_id", envelope.ErrInvalidEvent)
+	}
+
+	return nil
 }
 
 type NotificationCreatedProducer interface {
@@ -22,29 +38,39 @@ type NotificationCreatedProducer interface {
 
 func NewNotificationCreatedProducer(
 	producer Producer,
+	kafkaConfig configs.Kafka,
 	logger *zap.Logger,
 ) NotificationCreatedProducer {
 	return &notificationCreated{
 		producer: producer,
+		encoding: envelope.Encoding(kafkaConfig.Producer.EventEncoding),
 		logger:   logger,
 	}
 }
 
 type notificationCreated struct {
 	producer Producer
+	encoding envelope.Encoding
 	logger   *zap.Logger
 }
 
 func (n notificationCreated) Produce(ctx context.Context, event NotificationCreated) error {
 	logger := utils.LoggerWithContext(ctx, n.logger)
 
-	eventBytes, err := json.Marshal(event)
+	// Keyed by booking, like the version 1 events, so that the events of a booking stay on one partition.
+	message, err := newEventMessage(
+		fmt.Sprint(event.OfBookingId),
+		EventTypeNotificationCreated,
+		SchemaVersionNotificationCreated,
+		event,
+		n.encoding,
+	)
 	if err != nil {
-		logger.With(zap.Error(err)).Error("failed to marshal notification created event")
+		logger.With(zap.Error(err)).Error("failed to encode notification created event")
 		return err
 	}
 
-	err = n.producer.Produce(ctx, TopicNameNotificationServiceNotificationCreated, eventBytes)
+	err = n.producer.Produce(ctx, TopicNameNotificationServiceNotificationCreated, message)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to produce notification created event")
 		return err
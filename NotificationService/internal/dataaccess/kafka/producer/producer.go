@@ -2,57 +2,145 @@ package producer
 
 import (
 	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/kafka/security"
+	"NotificationService/internal/dataaccess/kafka/tracing"
 	"NotificationService/internal/utils"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const (
+	HeaderContentType   = "content-type"
+	HeaderEventType     = "event_type"
+	HeaderSchemaVersion = "schema_version"
+	HeaderRequestID     = "request_id"
+
+	defaultRetryMax = 3
+	tracerName      = "NotificationService/internal/dataaccess/kafka/producer"
+)
+
+type Message struct {
+	// Key decides the partition of the message, messages with the same key are consumed in order.
+	Key     string
+	Headers map[string]string
+	Payload []byte
+}
+
 type Producer interface {
-	Produce(ctx context.Context, queueName string, payload []byte) error
+	Produce(ctx context.Context, queueName string, message Message) error
+	// Close flushes the buffered messages and shuts the producer down.
+	Close() error
 }
 
-func newSaramaProducerConfig(kafkaConfig configs.Kafka) *sarama.Config {
+func newSaramaProducerConfig(kafkaConfig configs.Kafka) (*sarama.Config, error) {
+	producerConfig := kafkaConfig.Producer
+
 	config := sarama.NewConfig()
-	config.Producer.Retry.Max = 1
-	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Return.Successes = true
 	config.ClientID = kafkaConfig.ClientID
 	config.Metadata.Full = true
-	return config
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = defaultRetryMax
+	if producerConfig.RetryMax > 0 {
+		config.Producer.Retry.Max = producerConfig.RetryMax
+	}
+
+	if kafkaConfig.Version != "" {
+		version, err := sarama.ParseKafkaVersion(kafkaConfig.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kafka version: %w", err)
+		}
+		config.Version = version
+	}
+
+	if producerConfig.Compression != "" {
+		if err := config.Producer.Compression.UnmarshalText([]byte(producerConfig.Compression)); err != nil {
+			return nil, fmt.Errorf("invalid producer compression: %w", err)
+		}
+		if config.Producer.Compression == sarama.CompressionZSTD && !config.Version.IsAtLeast(sarama.V2_1_0_0) {
+			config.Version = sarama.V2_1_0_0
+		}
+	}
+
+	if producerConfig.Idempotent {
+		config.Producer.Idempotent = true
+		config.Net.MaxOpenRequests = 1
+		if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+			config.Version = sarama.V0_11_0_0
+		}
+	}
+
+	if producerConfig.Async {
+		config.Producer.Return.Successes = false
+		config.Producer.Return.Errors = true
+		config.Producer.Flush.Frequency = producerConfig.FlushFrequency
+		config.Producer.Flush.Messages = producerConfig.FlushMessages
+		config.Producer.Flush.Bytes = producerConfig.FlushBytes
+	} else {
+		config.Producer.Return.Successes = true
+	}
+
+	if err := security.Apply(config, kafkaConfig); err != nil {
+		return nil, err
+	}
+
+	return config, nil
 }
 
 func NewProducer(
 	kafkaConfig configs.Kafka,
+	tracerProvider trace.TracerProvider,
 	logger *zap.Logger,
 ) (Producer, error) {
-	saramaSyncProducer, err := sarama.NewSyncProducer(kafkaConfig.Addresses, newSaramaProducerConfig(kafkaConfig))
+	tracer := tracerProvider.Tracer(tracerName)
+
+	saramaConfig, err := newSaramaProducerConfig(kafkaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if kafkaConfig.Producer.Async {
+		saramaAsyncProducer, err := sarama.NewAsyncProducer(kafkaConfig.Addresses, saramaConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sarama async producer: %w", err)
+		}
+
+		return newAsyncProducer(saramaAsyncProducer, tracer, logger), nil
+	}
+
+	saramaSyncProducer, err := sarama.NewSyncProducer(kafkaConfig.Addresses, saramaConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sarama sync producer: %w", err)
 	}
 
 	return &producer{
 		saramaSyncProducer: saramaSyncProducer,
+		tracer:             tracer,
 		logger:             logger,
 	}, nil
 }
 
 type producer struct {
 	saramaSyncProducer sarama.SyncProducer
+	tracer             trace.Tracer
 	logger             *zap.Logger
 }
 
-func (p producer) Produce(ctx context.Context, queueName string, payload []byte) error {
+func (p producer) Produce(ctx context.Context, queueName string, message Message) error {
 	logger := utils.LoggerWithContext(ctx, p.logger).
-		With(zap.String("queue_name", queueName)).
-		With(zap.ByteString("payload", payload))
+		With(zap.String(utils.LogFieldQueueName, queueName)).
+		With(zap.String("key", message.Key)).
+		With(zap.ByteString("payload", message.Payload))
 
-	_, _, err := p.saramaSyncProducer.SendMessage(&sarama.ProducerMessage{
-		Topic: queueName,
-		Value: sarama.ByteEncoder(payload),
-	})
+	ctx, span := startSendSpan(ctx, p.tracer, queueName, message)
+	_, _, err := p.saramaSyncProducer.SendMessage(newSaramaProducerMessage(ctx, queueName, message))
+	utils.EndSpan(span, err)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to produce message")
 		return err
@@ -60,3 +148,106 @@ func (p producer) Produce(ctx context.Context, queueName string, payload []byte)
 
 	return nil
 }
+
+func (p producer) Close() error {
+	if err := p.saramaSyncProducer.Close(); err != nil {
+		p.logger.With(zap.Error(err)).Error("failed to close producer")
+		return err
+	}
+
+	return nil
+}
+
+// asyncProducer batches messages in the background. Produce returns as soon as the message is queued,
+// delivery failures are only logged.
+type asyncProducer struct {
+	saramaAsyncProducer sarama.AsyncProducer
+	errorsDrained       chan struct{}
+	tracer              trace.Tracer
+	logger              *zap.Logger
+}
+
+func newAsyncProducer(saramaAsyncProducer sarama.AsyncProducer, tracer trace.Tracer, logger *zap.Logger) Producer {
+	p := &asyncProducer{
+		saramaAsyncProducer: saramaAsyncProducer,
+		errorsDrained:       make(chan struct{}),
+		tracer:              tracer,
+		logger:              logger,
+	}
+
+	go func() {
+		defer close(p.errorsDrained)
+		for producerErr := range saramaAsyncProducer.Errors() {
+			p.logger.
+				With(zap.String(utils.LogFieldQueueName, producerErr.Msg.Topic)).
+				With(zap.Error(producerErr.Err)).
+				Error("failed to produce message")
+		}
+	}()
+
+	return p
+}
+
+// Produce only traces queueing the message, delivery happens after the span has ended.
+func (p *asyncProducer) Produce(ctx context.Context, queueName string, message Message) error {
+	ctx, span := startSendSpan(ctx, p.tracer, queueName, message)
+
+	select {
+	case p.saramaAsyncProducer.Input() <- newSaramaProducerMessage(ctx, queueName, message):
+		span.End()
+		return nil
+	case <-ctx.Done():
+		utils.EndSpan(span, ctx.Err())
+		return ctx.Err()
+	}
+}
+
+func (p *asyncProducer) Close() error {
+	err := p.saramaAsyncProducer.Close()
+	<-p.errorsDrained
+	if err != nil {
+		p.logger.With(zap.Error(err)).Error("failed to close producer")
+		return err
+	}
+
+	return nil
+}
+
+func startSendSpan(ctx context.Context, tracer trace.Tracer, queueName string, message Message) (context.Context, trace.Span) {
+	return tracer.Start(
+		ctx,
+		fmt.Sprintf("%s publish", queueName),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationPublish,
+			semconv.MessagingDestinationName(queueName),
+			semconv.MessagingKafkaMessageKey(message.Key),
+		),
+	)
+}
+
+// newSaramaProducerMessage builds the message of queueName, carrying the trace context and the request id
+// of ctx in its headers.
+func newSaramaProducerMessage(ctx context.Context, queueName string, message Message) *sarama.ProducerMessage {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+3)
+	for key, value := range message.Headers {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
+	if requestID := utils.RequestIDFromContext(ctx); requestID != "" {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(HeaderRequestID), Value: []byte(requestID)})
+	}
+
+	saramaMessage := &sarama.ProducerMessage{
+		Topic:     queueName,
+		Value:     sarama.ByteEncoder(message.Payload),
+		Headers:   headers,
+		Timestamp: time.Now(),
+	}
+	if message.Key != "" {
+		saramaMessage.Key = sarama.StringEncoder(message.Key)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, tracing.ProducerMessageCarrier{Message: saramaMessage})
+
+	return saramaMessage
+}
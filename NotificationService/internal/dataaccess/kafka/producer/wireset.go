@@ -5,4 +5,6 @@ import "github.com/google/wire"
 var WireSet = wire.NewSet(
 	NewProducer,
 	NewNotificationCreatedProducer,
+	NewEventQuarantineProducer,
+	NewEventDeadLetterProducer,
 )
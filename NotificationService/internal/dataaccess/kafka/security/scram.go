@@ -0,0 +1,44 @@
+package security
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+
+	"github.com/IBM/sarama"
+	"github.com/xdg-go/scram"
+)
+
+var (
+	sha256HashGenerator scram.HashGeneratorFcn = sha256.New
+	sha512HashGenerator scram.HashGeneratorFcn = sha512.New
+)
+
+// scramClient adapts a xdg-go/scram conversation to the sarama.SCRAMClient interface.
+type scramClient struct {
+	hashGenerator scram.HashGeneratorFcn
+	conversation  *scram.ClientConversation
+}
+
+func newSCRAMClientGenerator(hashGenerator scram.HashGeneratorFcn) func() sarama.SCRAMClient {
+	return func() sarama.SCRAMClient {
+		return &scramClient{hashGenerator: hashGenerator}
+	}
+}
+
+func (s *scramClient) Begin(userName, password, authzID string) error {
+	client, err := s.hashGenerator.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+
+	s.conversation = client.NewConversation()
+	return nil
+}
+
+func (s *scramClient) Step(challenge string) (string, error) {
+	return s.conversation.Step(challenge)
+}
+
+func (s *scramClient) Done() bool {
+	return s.conversation.Done()
+}
@@ -0,0 +1,91 @@
+package security
+
+import (
+	"NotificationService/internal/configs"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/IBM/sarama"
+)
+
+// Apply configures the SASL authentication and TLS encryption of the connections to the brokers.
+func Apply(config *sarama.Config, kafkaConfig configs.Kafka) error {
+	if err := applySASL(config, kafkaConfig.SASL); err != nil {
+		return err
+	}
+
+	return applyTLS(config, kafkaConfig.TLS)
+}
+
+func applySASL(config *sarama.Config, saslConfig configs.KafkaSASL) error {
+	if !saslConfig.Enabled {
+		return nil
+	}
+
+	config.Net.SASL.Enable = true
+	config.Net.SASL.Handshake = true
+	config.Net.SASL.User = saslConfig.Username
+	config.Net.SASL.Password = saslConfig.Password
+
+	switch saslConfig.Mechanism {
+	case configs.KafkaSASLMechanismPlain, "":
+		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case configs.KafkaSASLMechanismSCRAMSHA256:
+		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		config.Net.SASL.SCRAMClientGeneratorFunc = newSCRAMClientGenerator(sha256HashGenerator)
+	case configs.KafkaSASLMechanismSCRAMSHA512:
+		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		config.Net.SASL.SCRAMClientGeneratorFunc = newSCRAMClientGenerator(sha512HashGenerator)
+	default:
+		return fmt.Errorf("unsupported sasl mechanism: %s", saslConfig.Mechanism)
+	}
+
+	// SASL handshake version 1 is only supported from Kafka 1.0.0.
+	if !config.Version.IsAtLeast(sarama.V1_0_0_0) {
+		config.Version = sarama.V1_0_0_0
+	}
+
+	return nil
+}
+
+func applyTLS(config *sarama.Config, tlsConfig configs.KafkaTLS) error {
+	if !tlsConfig.Enabled {
+		return nil
+	}
+
+	clientTLSConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         tlsConfig.ServerName,
+		InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
+	}
+
+	if tlsConfig.CAFile != "" {
+		caPEM, err := os.ReadFile(tlsConfig.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read kafka ca file: %w", err)
+		}
+
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caPEM) {
+			return errors.New("failed to parse kafka ca file")
+		}
+
+		clientTLSConfig.RootCAs = certPool
+	}
+
+	if tlsConfig.CertFile != "" || tlsConfig.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load kafka client certificate: %w", err)
+		}
+
+		clientTLSConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	config.Net.TLS.Enable = true
+	config.Net.TLS.Config = clientTLSConfig
+	return nil
+}
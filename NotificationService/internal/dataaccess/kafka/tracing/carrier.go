@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// ProducerMessageCarrier carries the trace context of a produced message in its headers.
+type ProducerMessageCarrier struct {
+	Message *sarama.ProducerMessage
+}
+
+var _ propagation.TextMapCarrier = ProducerMessageCarrier{}
+
+func (c ProducerMessageCarrier) Get(key string) string {
+	for _, header := range c.Message.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+func (c ProducerMessageCarrier) Set(key string, value string) {
+	for i, header := range c.Message.Headers {
+		if string(header.Key) == key {
+			c.Message.Headers = append(c.Message.Headers[:i], c.Message.Headers[i+1:]...)
+			break
+		}
+	}
+
+	c.Message.Headers = append(c.Message.Headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+}
+
+func (c ProducerMessageCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.Message.Headers))
+	for _, header := range c.Message.Headers {
+		keys = append(keys, string(header.Key))
+	}
+
+	return keys
+}
+
+// ConsumerMessageCarrier reads the trace context of a consumed message from its headers.
+type ConsumerMessageCarrier struct {
+	Message *sarama.ConsumerMessage
+}
+
+var _ propagation.TextMapCarrier = ConsumerMessageCarrier{}
+
+func (c ConsumerMessageCarrier) Get(key string) string {
+	for _, header := range c.Message.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set is a no-op, consumed messages are read only.
+func (c ConsumerMessageCarrier) Set(string, string) {}
+
+func (c ConsumerMessageCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.Message.Headers))
+	for _, header := range c.Message.Headers {
+		if header != nil {
+			keys = append(keys, string(header.Key))
+		}
+	}
+
+	return keys
+}
@@ -2,13 +2,24 @@ package s3
 
 import (
 	"NotificationService/internal/configs"
+	"NotificationService/internal/metrics"
+	"NotificationService/internal/utils"
 	"context"
 	"io"
+	"time"
 
 	"github.com/minio/minio-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const (
+	tracerName = "NotificationService/internal/dataaccess/s3"
+
+	defaultPosterBucket = "posters"
+)
+
 type Client interface {
 	CreateBucketIfNotExist(ctx context.Context) error
 	UploadFile(ctx context.Context, fileName string, fileData io.Reader) error
@@ -18,11 +29,13 @@ type Client interface {
 type S3Client struct {
 	minioClient *minio.Client
 	bucket      string
+	tracer      trace.Tracer
 	logger      *zap.Logger
 }
 
 func NewClient(
 	s3Config configs.S3,
+	tracerProvider trace.TracerProvider,
 	logger *zap.Logger,
 ) (Client, error) {
 	minioClient, err := minio.New(s3Config.Address, s3Config.Username, s3Config.Password, false)
@@ -34,6 +47,36 @@ func NewClient(
 	return &S3Client{
 		minioClient: minioClient,
 		bucket:      s3Config.Bucket,
+		tracer:      tracerProvider.Tracer(tracerName),
+		logger:      logger,
+	}, nil
+}
+
+// PosterClient reads the movie posters stored by MovieService.
+type PosterClient interface {
+	GetFile(ctx context.Context, fileName string) ([]byte, error)
+}
+
+func NewPosterClient(
+	s3Config configs.S3,
+	tracerProvider trace.TracerProvider,
+	logger *zap.Logger,
+) (PosterClient, error) {
+	posterBucket := s3Config.PosterBucket
+	if posterBucket == "" {
+		posterBucket = defaultPosterBucket
+	}
+
+	minioClient, err := minio.New(s3Config.Address, s3Config.Username, s3Config.Password, false)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create minio client")
+		return nil, err
+	}
+
+	return &S3Client{
+		minioClient: minioClient,
+		bucket:      posterBucket,
+		tracer:      tracerProvider.Tracer(tracerName),
 		logger:      logger,
 	}, nil
 }
@@ -57,27 +100,48 @@ func (s *S3Client) CreateBucketIfNotExist(ctx context.Context) error {
 }
 
 func (s *S3Client) UploadFile(ctx context.Context, fileName string, fileData io.Reader) error {
+	_, span := s.startSpan(ctx, "s3.PutObject", fileName)
+	uploadStart := time.Now()
 	_, err := s.minioClient.PutObject(s.bucket, fileName, fileData, -1, minio.PutObjectOptions{})
+	metrics.ObserveDuration(metrics.S3UploadDuration, uploadStart, err)
+	utils.EndSpan(span, err)
 	if err != nil {
-		s.logger.With(zap.Error(err)).Error("failed to upload file")
+		utils.LoggerWithContext(ctx, s.logger).With(zap.String("file_name", fileName)).With(zap.Error(err)).Error("failed to upload file")
 		return err
 	}
 	return nil
 }
 
-func (s *S3Client) GetFile(ctx context.Context, fileName string) ([]byte, error) {
+func (s *S3Client) GetFile(ctx context.Context, fileName string) (data []byte, err error) {
+	ctx, span := s.startSpan(ctx, "s3.GetObject", fileName)
+	defer func() {
+		utils.EndSpan(span, err)
+	}()
+
 	object, err := s.minioClient.GetObjectWithContext(ctx, s.bucket, fileName, minio.GetObjectOptions{})
 	if err != nil {
-		s.logger.With(zap.Error(err)).Error("failed to get file")
+		utils.LoggerWithContext(ctx, s.logger).With(zap.String("file_name", fileName)).With(zap.Error(err)).Error("failed to get file")
 		return nil, err
 	}
 
 	defer object.Close()
-	data, err := io.ReadAll(object)
+	data, err = io.ReadAll(object)
 	if err != nil {
-		s.logger.With(zap.Error(err)).Error("failed to read file data")
+		utils.LoggerWithContext(ctx, s.logger).With(zap.String("file_name", fileName)).With(zap.Error(err)).Error("failed to read file data")
 		return nil, err
 	}
 
 	return data, nil
 }
+
+func (s *S3Client) startSpan(ctx context.Context, name string, fileName string) (context.Context, trace.Span) {
+	return s.tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("s3.bucket", s.bucket),
+			attribute.String("s3.key", fileName),
+		),
+	)
+}
@@ -1,6 +1,7 @@
 package dataaccess
 
 import (
+	"NotificationService/internal/dataaccess/cache"
 	"NotificationService/internal/dataaccess/database"
 	"NotificationService/internal/dataaccess/kafka"
 	"NotificationService/internal/dataaccess/s3"
@@ -9,6 +10,7 @@ import (
 )
 
 var WireSet = wire.NewSet(
+	cache.WireSet,
 	database.WireSet,
 	kafka.WireSet,
 	s3.WireSet,
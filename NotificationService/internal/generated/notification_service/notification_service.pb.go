@@ -20,6 +20,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DeliveryAttemptStatus int32
+
+const (
+	DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_UNSPECIFIED DeliveryAttemptStatus = 0
+	DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_SUCCESS     DeliveryAttemptStatus = 1
+	DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_FAILED      DeliveryAttemptStatus = 2
+)
+
+// Enum value maps for DeliveryAttemptStatus.
+var (
+	DeliveryAttemptStatus_name = map[int32]string{
+		0: "DELIVERY_ATTEMPT_STATUS_UNSPECIFIED",
+		1: "DELIVERY_ATTEMPT_STATUS_SUCCESS",
+		2: "DELIVERY_ATTEMPT_STATUS_FAILED",
+	}
+	DeliveryAttemptStatus_value = map[string]int32{
+		"DELIVERY_ATTEMPT_STATUS_UNSPECIFIED": 0,
+		"DELIVERY_ATTEMPT_STATUS_SUCCESS":     1,
+		"DELIVERY_ATTEMPT_STATUS_FAILED":      2,
+	}
+)
+
+func (x DeliveryAttemptStatus) Enum() *DeliveryAttemptStatus {
+	p := new(DeliveryAttemptStatus)
+	*p = x
+	return p
+}
+
+func (x DeliveryAttemptStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeliveryAttemptStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_notification_service_notification_service_proto_enumTypes[0].Descriptor()
+}
+
+func (DeliveryAttemptStatus) Type() protoreflect.EnumType {
+	return &file_notification_service_notification_service_proto_enumTypes[0]
+}
+
+func (x DeliveryAttemptStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeliveryAttemptStatus.Descriptor instead.
+func (DeliveryAttemptStatus) EnumDescriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{0}
+}
+
 type HelloRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -114,6 +163,561 @@ func (x *HelloResponse) GetMessage() string {
 	return ""
 }
 
+type DeliveryAttempt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               uint32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OfNotificationId uint32                `protobuf:"varint,2,opt,name=of_notification_id,json=ofNotificationId,proto3" json:"of_notification_id,omitempty"`
+	OfBookingId      uint32                `protobuf:"varint,3,opt,name=of_booking_id,json=ofBookingId,proto3" json:"of_booking_id,omitempty"`
+	Channel          string                `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`
+	Recipient        string                `protobuf:"bytes,5,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Status           DeliveryAttemptStatus `protobuf:"varint,6,opt,name=status,proto3,enum=notification_service.DeliveryAttemptStatus" json:"status,omitempty"`
+	MessageId        string                `protobuf:"bytes,7,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	TemplateVersion  string                `protobuf:"bytes,8,opt,name=template_version,json=templateVersion,proto3" json:"template_version,omitempty"`
+	ErrorClass       string                `protobuf:"bytes,9,opt,name=error_class,json=errorClass,proto3" json:"error_class,omitempty"`
+	ProviderResponse string                `protobuf:"bytes,10,opt,name=provider_response,json=providerResponse,proto3" json:"provider_response,omitempty"`
+	// Unix milliseconds.
+	StartedAt  uint64 `protobuf:"varint,11,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt uint64 `protobuf:"varint,12,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+}
+
+func (x *DeliveryAttempt) Reset() {
+	*x = DeliveryAttempt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notification_service_notification_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAttempt) ProtoMessage() {}
+
+func (x *DeliveryAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_service_notification_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAttempt.ProtoReflect.Descriptor instead.
+func (*DeliveryAttempt) Descriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeliveryAttempt) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetOfNotificationId() uint32 {
+	if x != nil {
+		return x.OfNotificationId
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetOfBookingId() uint32 {
+	if x != nil {
+		return x.OfBookingId
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetStatus() DeliveryAttemptStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_UNSPECIFIED
+}
+
+func (x *DeliveryAttempt) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetTemplateVersion() string {
+	if x != nil {
+		return x.TemplateVersion
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetErrorClass() string {
+	if x != nil {
+		return x.ErrorClass
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetProviderResponse() string {
+	if x != nil {
+		return x.ProviderResponse
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetStartedAt() uint64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetFinishedAt() uint64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+type GetDeliveryAttemptListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BookingId uint32 `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+}
+
+func (x *GetDeliveryAttemptListRequest) Reset() {
+	*x = GetDeliveryAttemptListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notification_service_notification_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeliveryAttemptListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryAttemptListRequest) ProtoMessage() {}
+
+func (x *GetDeliveryAttemptListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_service_notification_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryAttemptListRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliveryAttemptListRequest) Descriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetDeliveryAttemptListRequest) GetBookingId() uint32 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+type GetDeliveryAttemptListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeliveryAttemptList []*DeliveryAttempt `protobuf:"bytes,1,rep,name=delivery_attempt_list,json=deliveryAttemptList,proto3" json:"delivery_attempt_list,omitempty"`
+}
+
+func (x *GetDeliveryAttemptListResponse) Reset() {
+	*x = GetDeliveryAttemptListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notification_service_notification_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeliveryAttemptListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryAttemptListResponse) ProtoMessage() {}
+
+func (x *GetDeliveryAttemptListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_service_notification_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryAttemptListResponse.ProtoReflect.Descriptor instead.
+func (*GetDeliveryAttemptListResponse) Descriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDeliveryAttemptListResponse) GetDeliveryAttemptList() []*DeliveryAttempt {
+	if x != nil {
+		return x.DeliveryAttemptList
+	}
+	return nil
+}
+
+type TicketCheckIn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OfBookingId  uint32 `protobuf:"varint,2,opt,name=of_booking_id,json=ofBookingId,proto3" json:"of_booking_id,omitempty"`
+	OfShowtimeId uint32 `protobuf:"varint,3,opt,name=of_showtime_id,json=ofShowtimeId,proto3" json:"of_showtime_id,omitempty"`
+	OfSeatId     uint32 `protobuf:"varint,4,opt,name=of_seat_id,json=ofSeatId,proto3" json:"of_seat_id,omitempty"`
+	Gate         string `protobuf:"bytes,5,opt,name=gate,proto3" json:"gate,omitempty"`
+	StaffId      uint32 `protobuf:"varint,6,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	// Unix milliseconds.
+	CheckedInAt uint64 `protobuf:"varint,7,opt,name=checked_in_at,json=checkedInAt,proto3" json:"checked_in_at,omitempty"`
+}
+
+func (x *TicketCheckIn) Reset() {
+	*x = TicketCheckIn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notification_service_notification_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TicketCheckIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketCheckIn) ProtoMessage() {}
+
+func (x *TicketCheckIn) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_service_notification_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketCheckIn.ProtoReflect.Descriptor instead.
+func (*TicketCheckIn) Descriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TicketCheckIn) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TicketCheckIn) GetOfBookingId() uint32 {
+	if x != nil {
+		return x.OfBookingId
+	}
+	return 0
+}
+
+func (x *TicketCheckIn) GetOfShowtimeId() uint32 {
+	if x != nil {
+		return x.OfShowtimeId
+	}
+	return 0
+}
+
+func (x *TicketCheckIn) GetOfSeatId() uint32 {
+	if x != nil {
+		return x.OfSeatId
+	}
+	return 0
+}
+
+func (x *TicketCheckIn) GetGate() string {
+	if x != nil {
+		return x.Gate
+	}
+	return ""
+}
+
+func (x *TicketCheckIn) GetStaffId() uint32 {
+	if x != nil {
+		return x.StaffId
+	}
+	return 0
+}
+
+func (x *TicketCheckIn) GetCheckedInAt() uint64 {
+	if x != nil {
+		return x.CheckedInAt
+	}
+	return 0
+}
+
+type CheckInTicketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The token encoded in the QR code of the ticket.
+	TicketToken string `protobuf:"bytes,1,opt,name=ticket_token,json=ticketToken,proto3" json:"ticket_token,omitempty"`
+	Gate        string `protobuf:"bytes,2,opt,name=gate,proto3" json:"gate,omitempty"`
+}
+
+func (x *CheckInTicketRequest) Reset() {
+	*x = CheckInTicketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notification_service_notification_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckInTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInTicketRequest) ProtoMessage() {}
+
+func (x *CheckInTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_service_notification_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInTicketRequest.ProtoReflect.Descriptor instead.
+func (*CheckInTicketRequest) Descriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CheckInTicketRequest) GetTicketToken() string {
+	if x != nil {
+		return x.TicketToken
+	}
+	return ""
+}
+
+func (x *CheckInTicketRequest) GetGate() string {
+	if x != nil {
+		return x.Gate
+	}
+	return ""
+}
+
+type CheckInTicketResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TicketCheckIn *TicketCheckIn `protobuf:"bytes,1,opt,name=ticket_check_in,json=ticketCheckIn,proto3" json:"ticket_check_in,omitempty"`
+}
+
+func (x *CheckInTicketResponse) Reset() {
+	*x = CheckInTicketResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notification_service_notification_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckInTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInTicketResponse) ProtoMessage() {}
+
+func (x *CheckInTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_service_notification_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInTicketResponse.ProtoReflect.Descriptor instead.
+func (*CheckInTicketResponse) Descriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckInTicketResponse) GetTicketCheckIn() *TicketCheckIn {
+	if x != nil {
+		return x.TicketCheckIn
+	}
+	return nil
+}
+
+type GetShowtimeCheckInReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShowtimeId uint32 `protobuf:"varint,1,opt,name=showtime_id,json=showtimeId,proto3" json:"showtime_id,omitempty"`
+}
+
+func (x *GetShowtimeCheckInReportRequest) Reset() {
+	*x = GetShowtimeCheckInReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notification_service_notification_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetShowtimeCheckInReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShowtimeCheckInReportRequest) ProtoMessage() {}
+
+func (x *GetShowtimeCheckInReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_service_notification_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShowtimeCheckInReportRequest.ProtoReflect.Descriptor instead.
+func (*GetShowtimeCheckInReportRequest) Descriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetShowtimeCheckInReportRequest) GetShowtimeId() uint32 {
+	if x != nil {
+		return x.ShowtimeId
+	}
+	return 0
+}
+
+type GetShowtimeCheckInReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShowtimeId                uint32           `protobuf:"varint,1,opt,name=showtime_id,json=showtimeId,proto3" json:"showtime_id,omitempty"`
+	ConfirmedBookingCount     uint32           `protobuf:"varint,2,opt,name=confirmed_booking_count,json=confirmedBookingCount,proto3" json:"confirmed_booking_count,omitempty"`
+	CheckedInCount            uint32           `protobuf:"varint,3,opt,name=checked_in_count,json=checkedInCount,proto3" json:"checked_in_count,omitempty"`
+	NotCheckedInBookingIdList []uint32         `protobuf:"varint,4,rep,packed,name=not_checked_in_booking_id_list,json=notCheckedInBookingIdList,proto3" json:"not_checked_in_booking_id_list,omitempty"`
+	TicketCheckInList         []*TicketCheckIn `protobuf:"bytes,5,rep,name=ticket_check_in_list,json=ticketCheckInList,proto3" json:"ticket_check_in_list,omitempty"`
+}
+
+func (x *GetShowtimeCheckInReportResponse) Reset() {
+	*x = GetShowtimeCheckInReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_notification_service_notification_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetShowtimeCheckInReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShowtimeCheckInReportResponse) ProtoMessage() {}
+
+func (x *GetShowtimeCheckInReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_service_notification_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShowtimeCheckInReportResponse.ProtoReflect.Descriptor instead.
+func (*GetShowtimeCheckInReportResponse) Descriptor() ([]byte, []int) {
+	return file_notification_service_notification_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetShowtimeCheckInReportResponse) GetShowtimeId() uint32 {
+	if x != nil {
+		return x.ShowtimeId
+	}
+	return 0
+}
+
+func (x *GetShowtimeCheckInReportResponse) GetConfirmedBookingCount() uint32 {
+	if x != nil {
+		return x.ConfirmedBookingCount
+	}
+	return 0
+}
+
+func (x *GetShowtimeCheckInReportResponse) GetCheckedInCount() uint32 {
+	if x != nil {
+		return x.CheckedInCount
+	}
+	return 0
+}
+
+func (x *GetShowtimeCheckInReportResponse) GetNotCheckedInBookingIdList() []uint32 {
+	if x != nil {
+		return x.NotCheckedInBookingIdList
+	}
+	return nil
+}
+
+func (x *GetShowtimeCheckInReportResponse) GetTicketCheckInList() []*TicketCheckIn {
+	if x != nil {
+		return x.TicketCheckInList
+	}
+	return nil
+}
+
 var File_notification_service_notification_service_proto protoreflect.FileDescriptor
 
 var file_notification_service_notification_service_proto_rawDesc = []byte{
@@ -127,27 +731,151 @@ var file_notification_service_notification_service_proto_rawDesc = []byte{
 	0x6b, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x22, 0x29, 0x0a, 0x0d, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x32, 0x6c, 0x0a, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x08, 0x53, 0x61, 0x79, 0x48,
-	0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x22, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x48, 0x65, 0x6c, 0x6c,
-	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66,
+	0x65, 0x22, 0xc8, 0x03, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x6f, 0x66, 0x5f, 0x6e, 0x6f, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x10, 0x6f, 0x66, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x66, 0x5f, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e,
+	0x67, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6f, 0x66, 0x42, 0x6f,
+	0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12,
+	0x43, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x2b, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f,
+	0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12,
+	0x2b, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x66,
+	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x22, 0x3e, 0x0a, 0x1d,
+	0x47, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x22, 0x7b, 0x0a, 0x1e,
+	0x47, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59,
+	0x0a, 0x15, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x52, 0x13, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x22, 0xda, 0x01, 0x0a, 0x0d, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6f,
+	0x66, 0x5f, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x6f, 0x66, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x12,
+	0x24, 0x0a, 0x0e, 0x6f, 0x66, 0x5f, 0x73, 0x68, 0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6f, 0x66, 0x53, 0x68, 0x6f, 0x77, 0x74,
+	0x69, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x0a, 0x6f, 0x66, 0x5f, 0x73, 0x65, 0x61, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6f, 0x66, 0x53, 0x65, 0x61,
+	0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x67, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x66, 0x66,
+	0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x74, 0x61, 0x66, 0x66,
+	0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x69, 0x6e,
+	0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x65, 0x64, 0x49, 0x6e, 0x41, 0x74, 0x22, 0x5d, 0x0a, 0x14, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49,
+	0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x67, 0x61, 0x74, 0x65, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x52, 0x08, 0x73, 0x74, 0x61,
+	0x66, 0x66, 0x5f, 0x69, 0x64, 0x22, 0x64, 0x0a, 0x15, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b,
+	0x0a, 0x0f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x69,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52, 0x0d, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x22, 0x42, 0x0a, 0x1f, 0x47,
+	0x65, 0x74, 0x53, 0x68, 0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49,
+	0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x68, 0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x68, 0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65, 0x49, 0x64, 0x22,
+	0xbe, 0x02, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x68, 0x6f, 0x77, 0x74,
+	0x69, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x65, 0x64, 0x5f, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65,
+	0x64, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a,
+	0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64,
+	0x49, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x1e, 0x6e, 0x6f, 0x74, 0x5f, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e,
+	0x67, 0x5f, 0x69, 0x64, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52,
+	0x19, 0x6e, 0x6f, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x49, 0x6e, 0x42, 0x6f, 0x6f,
+	0x6b, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x54, 0x0a, 0x14, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x69, 0x6e, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66,
 	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
-	0xcb, 0x01, 0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x18, 0x4e, 0x6f,
-	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x29, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x6e,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52, 0x11, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x4c, 0x69, 0x73, 0x74,
+	0x2a, 0x89, 0x01, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x23, 0x44, 0x45,
+	0x4c, 0x49, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x4d, 0x50, 0x54, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x44, 0x45, 0x4c, 0x49, 0x56, 0x45, 0x52, 0x59, 0x5f,
+	0x41, 0x54, 0x54, 0x45, 0x4d, 0x50, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53,
+	0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x44, 0x45, 0x4c, 0x49,
+	0x56, 0x45, 0x52, 0x59, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x4d, 0x50, 0x54, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x32, 0xee, 0x03, 0x0a,
+	0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x08, 0x53, 0x61, 0x79, 0x48, 0x65, 0x6c, 0x6c, 0x6f,
+	0x12, 0x22, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x48, 0x65, 0x6c, 0x6c,
+	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x85, 0x01, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x33, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x6e, 0x6f,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x6a, 0x0a, 0x0d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x12, 0x2a, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x49, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2b, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x8b, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x35, 0x2e, 0x6e,
 	0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0xa2, 0x02, 0x03, 0x4e, 0x58, 0x58, 0xaa, 0x02, 0x13, 0x4e, 0x6f, 0x74, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0xca,
-	0x02, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0xe2, 0x02, 0x1f, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5c, 0x47, 0x50, 0x42, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x36, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x68,
+	0x6f, 0x77, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0xcb, 0x01,
+	0x0a, 0x18, 0x63, 0x6f, 0x6d, 0x2e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x18, 0x4e, 0x6f, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x29, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x6e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0xa2, 0x02, 0x03, 0x4e, 0x58, 0x58, 0xaa, 0x02, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0xca, 0x02, 0x13,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0xe2, 0x02, 0x1f, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -162,19 +890,39 @@ func file_notification_service_notification_service_proto_rawDescGZIP() []byte {
 	return file_notification_service_notification_service_proto_rawDescData
 }
 
-var file_notification_service_notification_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_notification_service_notification_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_notification_service_notification_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_notification_service_notification_service_proto_goTypes = []any{
-	(*HelloRequest)(nil),  // 0: notification_service.HelloRequest
-	(*HelloResponse)(nil), // 1: notification_service.HelloResponse
+	(DeliveryAttemptStatus)(0),               // 0: notification_service.DeliveryAttemptStatus
+	(*HelloRequest)(nil),                     // 1: notification_service.HelloRequest
+	(*HelloResponse)(nil),                    // 2: notification_service.HelloResponse
+	(*DeliveryAttempt)(nil),                  // 3: notification_service.DeliveryAttempt
+	(*GetDeliveryAttemptListRequest)(nil),    // 4: notification_service.GetDeliveryAttemptListRequest
+	(*GetDeliveryAttemptListResponse)(nil),   // 5: notification_service.GetDeliveryAttemptListResponse
+	(*TicketCheckIn)(nil),                    // 6: notification_service.TicketCheckIn
+	(*CheckInTicketRequest)(nil),             // 7: notification_service.CheckInTicketRequest
+	(*CheckInTicketResponse)(nil),            // 8: notification_service.CheckInTicketResponse
+	(*GetShowtimeCheckInReportRequest)(nil),  // 9: notification_service.GetShowtimeCheckInReportRequest
+	(*GetShowtimeCheckInReportResponse)(nil), // 10: notification_service.GetShowtimeCheckInReportResponse
 }
 var file_notification_service_notification_service_proto_depIdxs = []int32{
-	0, // 0: notification_service.NotificationService.SayHello:input_type -> notification_service.HelloRequest
-	1, // 1: notification_service.NotificationService.SayHello:output_type -> notification_service.HelloResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: notification_service.DeliveryAttempt.status:type_name -> notification_service.DeliveryAttemptStatus
+	3,  // 1: notification_service.GetDeliveryAttemptListResponse.delivery_attempt_list:type_name -> notification_service.DeliveryAttempt
+	6,  // 2: notification_service.CheckInTicketResponse.ticket_check_in:type_name -> notification_service.TicketCheckIn
+	6,  // 3: notification_service.GetShowtimeCheckInReportResponse.ticket_check_in_list:type_name -> notification_service.TicketCheckIn
+	1,  // 4: notification_service.NotificationService.SayHello:input_type -> notification_service.HelloRequest
+	4,  // 5: notification_service.NotificationService.GetDeliveryAttemptList:input_type -> notification_service.GetDeliveryAttemptListRequest
+	7,  // 6: notification_service.NotificationService.CheckInTicket:input_type -> notification_service.CheckInTicketRequest
+	9,  // 7: notification_service.NotificationService.GetShowtimeCheckInReport:input_type -> notification_service.GetShowtimeCheckInReportRequest
+	2,  // 8: notification_service.NotificationService.SayHello:output_type -> notification_service.HelloResponse
+	5,  // 9: notification_service.NotificationService.GetDeliveryAttemptList:output_type -> notification_service.GetDeliveryAttemptListResponse
+	8,  // 10: notification_service.NotificationService.CheckInTicket:output_type -> notification_service.CheckInTicketResponse
+	10, // 11: notification_service.NotificationService.GetShowtimeCheckInReport:output_type -> notification_service.GetShowtimeCheckInReportResponse
+	8,  // [8:12] is the sub-list for method output_type
+	4,  // [4:8] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_notification_service_notification_service_proto_init() }
@@ -207,19 +955,116 @@ func file_notification_service_notification_service_proto_init() {
 				return nil
 			}
 		}
+		file_notification_service_notification_service_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*DeliveryAttempt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_notification_service_notification_service_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetDeliveryAttemptListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_notification_service_notification_service_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetDeliveryAttemptListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_notification_service_notification_service_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*TicketCheckIn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_notification_service_notification_service_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*CheckInTicketRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_notification_service_notification_service_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*CheckInTicketResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_notification_service_notification_service_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*GetShowtimeCheckInReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_notification_service_notification_service_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetShowtimeCheckInReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_notification_service_notification_service_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_notification_service_notification_service_proto_goTypes,
 		DependencyIndexes: file_notification_service_notification_service_proto_depIdxs,
+		EnumInfos:         file_notification_service_notification_service_proto_enumTypes,
 		MessageInfos:      file_notification_service_notification_service_proto_msgTypes,
 	}.Build()
 	File_notification_service_notification_service_proto = out.File
@@ -67,7 +67,8 @@ func (m *HelloRequest) validate(all bool) error {
 }
 
 // HelloRequestMultiError is an error wrapping multiple validation errors
-// returned by HelloRequest.ValidateAll() if the designated constraints aren't met.
+// returned by HelloRequest.ValidateAll() if the designated constraints aren't
+// met.
 type HelloRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
@@ -168,8 +169,8 @@ func (m *HelloResponse) validate(all bool) error {
 }
 
 // HelloResponseMultiError is an error wrapping multiple validation errors
-// returned by HelloResponse.ValidateAll() if the designated constraints
-// aren't met.
+// returned by HelloResponse.ValidateAll() if the designated constraints aren't
+// met.
 type HelloResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
@@ -237,3 +238,974 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = HelloResponseValidationError{}
+
+// Validate checks the field values on DeliveryAttempt with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeliveryAttempt) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeliveryAttempt with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// DeliveryAttemptMultiError, or nil if none found.
+func (m *DeliveryAttempt) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeliveryAttempt) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for OfNotificationId
+
+	// no validation rules for OfBookingId
+
+	// no validation rules for Channel
+
+	// no validation rules for Recipient
+
+	// no validation rules for Status
+
+	// no validation rules for MessageId
+
+	// no validation rules for TemplateVersion
+
+	// no validation rules for ErrorClass
+
+	// no validation rules for ProviderResponse
+
+	// no validation rules for StartedAt
+
+	// no validation rules for FinishedAt
+
+	if len(errors) > 0 {
+		return DeliveryAttemptMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeliveryAttemptMultiError is an error wrapping multiple validation errors
+// returned by DeliveryAttempt.ValidateAll() if the designated constraints
+// aren't met.
+type DeliveryAttemptMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeliveryAttemptMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeliveryAttemptMultiError) AllErrors() []error { return m }
+
+// DeliveryAttemptValidationError is the validation error returned by
+// DeliveryAttempt.Validate if the designated constraints aren't met.
+type DeliveryAttemptValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeliveryAttemptValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeliveryAttemptValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeliveryAttemptValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeliveryAttemptValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeliveryAttemptValidationError) ErrorName() string { return "DeliveryAttemptValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DeliveryAttemptValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeliveryAttempt.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeliveryAttemptValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeliveryAttemptValidationError{}
+
+// Validate checks the field values on GetDeliveryAttemptListRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *GetDeliveryAttemptListRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetDeliveryAttemptListRequest with
+// the rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetDeliveryAttemptListRequestMultiError, or nil if none found.
+func (m *GetDeliveryAttemptListRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetDeliveryAttemptListRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for BookingId
+
+	if len(errors) > 0 {
+		return GetDeliveryAttemptListRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetDeliveryAttemptListRequestMultiError is an error wrapping multiple
+// validation errors returned by GetDeliveryAttemptListRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetDeliveryAttemptListRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetDeliveryAttemptListRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetDeliveryAttemptListRequestMultiError) AllErrors() []error { return m }
+
+// GetDeliveryAttemptListRequestValidationError is the validation error
+// returned by GetDeliveryAttemptListRequest.Validate if the designated
+// constraints aren't met.
+type GetDeliveryAttemptListRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetDeliveryAttemptListRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetDeliveryAttemptListRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetDeliveryAttemptListRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetDeliveryAttemptListRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetDeliveryAttemptListRequestValidationError) ErrorName() string {
+	return "GetDeliveryAttemptListRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetDeliveryAttemptListRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetDeliveryAttemptListRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetDeliveryAttemptListRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetDeliveryAttemptListRequestValidationError{}
+
+// Validate checks the field values on GetDeliveryAttemptListResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *GetDeliveryAttemptListResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetDeliveryAttemptListResponse with
+// the rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetDeliveryAttemptListResponseMultiError, or nil if none found.
+func (m *GetDeliveryAttemptListResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetDeliveryAttemptListResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetDeliveryAttemptList() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetDeliveryAttemptListResponseValidationError{
+						field:  fmt.Sprintf("DeliveryAttemptList[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetDeliveryAttemptListResponseValidationError{
+						field:  fmt.Sprintf("DeliveryAttemptList[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetDeliveryAttemptListResponseValidationError{
+					field:  fmt.Sprintf("DeliveryAttemptList[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetDeliveryAttemptListResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetDeliveryAttemptListResponseMultiError is an error wrapping multiple
+// validation errors returned by GetDeliveryAttemptListResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetDeliveryAttemptListResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetDeliveryAttemptListResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetDeliveryAttemptListResponseMultiError) AllErrors() []error { return m }
+
+// GetDeliveryAttemptListResponseValidationError is the validation error
+// returned by GetDeliveryAttemptListResponse.Validate if the designated
+// constraints aren't met.
+type GetDeliveryAttemptListResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetDeliveryAttemptListResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetDeliveryAttemptListResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetDeliveryAttemptListResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetDeliveryAttemptListResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetDeliveryAttemptListResponseValidationError) ErrorName() string {
+	return "GetDeliveryAttemptListResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetDeliveryAttemptListResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetDeliveryAttemptListResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetDeliveryAttemptListResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetDeliveryAttemptListResponseValidationError{}
+
+// Validate checks the field values on TicketCheckIn with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TicketCheckIn) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TicketCheckIn with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TicketCheckInMultiError, or
+// nil if none found.
+func (m *TicketCheckIn) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TicketCheckIn) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for OfBookingId
+
+	// no validation rules for OfShowtimeId
+
+	// no validation rules for OfSeatId
+
+	// no validation rules for Gate
+
+	// no validation rules for StaffId
+
+	// no validation rules for CheckedInAt
+
+	if len(errors) > 0 {
+		return TicketCheckInMultiError(errors)
+	}
+
+	return nil
+}
+
+// TicketCheckInMultiError is an error wrapping multiple validation errors
+// returned by TicketCheckIn.ValidateAll() if the designated constraints aren't
+// met.
+type TicketCheckInMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TicketCheckInMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TicketCheckInMultiError) AllErrors() []error { return m }
+
+// TicketCheckInValidationError is the validation error returned by
+// TicketCheckIn.Validate if the designated constraints aren't met.
+type TicketCheckInValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TicketCheckInValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TicketCheckInValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TicketCheckInValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TicketCheckInValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TicketCheckInValidationError) ErrorName() string { return "TicketCheckInValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TicketCheckInValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTicketCheckIn.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TicketCheckInValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TicketCheckInValidationError{}
+
+// Validate checks the field values on CheckInTicketRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *CheckInTicketRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckInTicketRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// CheckInTicketRequestMultiError, or nil if none found.
+func (m *CheckInTicketRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckInTicketRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for TicketToken
+
+	// no validation rules for Gate
+
+	if len(errors) > 0 {
+		return CheckInTicketRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckInTicketRequestMultiError is an error wrapping multiple validation
+// errors returned by CheckInTicketRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CheckInTicketRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckInTicketRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckInTicketRequestMultiError) AllErrors() []error { return m }
+
+// CheckInTicketRequestValidationError is the validation error returned by
+// CheckInTicketRequest.Validate if the designated constraints aren't met.
+type CheckInTicketRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckInTicketRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckInTicketRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckInTicketRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckInTicketRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckInTicketRequestValidationError) ErrorName() string {
+	return "CheckInTicketRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckInTicketRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckInTicketRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckInTicketRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckInTicketRequestValidationError{}
+
+// Validate checks the field values on CheckInTicketResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the first error encountered is returned, or nil if there are no violations.
+func (m *CheckInTicketResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckInTicketResponse with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// CheckInTicketResponseMultiError, or nil if none found.
+func (m *CheckInTicketResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckInTicketResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetTicketCheckIn()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CheckInTicketResponseValidationError{
+					field:  "TicketCheckIn",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CheckInTicketResponseValidationError{
+					field:  "TicketCheckIn",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTicketCheckIn()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CheckInTicketResponseValidationError{
+				field:  "TicketCheckIn",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CheckInTicketResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckInTicketResponseMultiError is an error wrapping multiple validation
+// errors returned by CheckInTicketResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CheckInTicketResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckInTicketResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckInTicketResponseMultiError) AllErrors() []error { return m }
+
+// CheckInTicketResponseValidationError is the validation error returned by
+// CheckInTicketResponse.Validate if the designated constraints aren't met.
+type CheckInTicketResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckInTicketResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckInTicketResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckInTicketResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckInTicketResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckInTicketResponseValidationError) ErrorName() string {
+	return "CheckInTicketResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckInTicketResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckInTicketResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckInTicketResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckInTicketResponseValidationError{}
+
+// Validate checks the field values on GetShowtimeCheckInReportRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *GetShowtimeCheckInReportRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetShowtimeCheckInReportRequest with
+// the rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetShowtimeCheckInReportRequestMultiError, or nil if none found.
+func (m *GetShowtimeCheckInReportRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetShowtimeCheckInReportRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ShowtimeId
+
+	if len(errors) > 0 {
+		return GetShowtimeCheckInReportRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetShowtimeCheckInReportRequestMultiError is an error wrapping multiple
+// validation errors returned by GetShowtimeCheckInReportRequest.ValidateAll()
+// if the designated constraints aren't met.
+type GetShowtimeCheckInReportRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetShowtimeCheckInReportRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetShowtimeCheckInReportRequestMultiError) AllErrors() []error { return m }
+
+// GetShowtimeCheckInReportRequestValidationError is the validation error
+// returned by GetShowtimeCheckInReportRequest.Validate if the designated
+// constraints aren't met.
+type GetShowtimeCheckInReportRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetShowtimeCheckInReportRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetShowtimeCheckInReportRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetShowtimeCheckInReportRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetShowtimeCheckInReportRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetShowtimeCheckInReportRequestValidationError) ErrorName() string {
+	return "GetShowtimeCheckInReportRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetShowtimeCheckInReportRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetShowtimeCheckInReportRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetShowtimeCheckInReportRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetShowtimeCheckInReportRequestValidationError{}
+
+// Validate checks the field values on GetShowtimeCheckInReportResponse with
+// the rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *GetShowtimeCheckInReportResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetShowtimeCheckInReportResponse with
+// the rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetShowtimeCheckInReportResponseMultiError, or nil if none found.
+func (m *GetShowtimeCheckInReportResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetShowtimeCheckInReportResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ShowtimeId
+
+	// no validation rules for ConfirmedBookingCount
+
+	// no validation rules for CheckedInCount
+
+	// no validation rules for NotCheckedInBookingIdList
+
+	for idx, item := range m.GetTicketCheckInList() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetShowtimeCheckInReportResponseValidationError{
+						field:  fmt.Sprintf("TicketCheckInList[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetShowtimeCheckInReportResponseValidationError{
+						field:  fmt.Sprintf("TicketCheckInList[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetShowtimeCheckInReportResponseValidationError{
+					field:  fmt.Sprintf("TicketCheckInList[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetShowtimeCheckInReportResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetShowtimeCheckInReportResponseMultiError is an error wrapping multiple
+// validation errors returned by GetShowtimeCheckInReportResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetShowtimeCheckInReportResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetShowtimeCheckInReportResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetShowtimeCheckInReportResponseMultiError) AllErrors() []error { return m }
+
+// GetShowtimeCheckInReportResponseValidationError is the validation error
+// returned by GetShowtimeCheckInReportResponse.Validate if the designated
+// constraints aren't met.
+type GetShowtimeCheckInReportResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetShowtimeCheckInReportResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetShowtimeCheckInReportResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetShowtimeCheckInReportResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetShowtimeCheckInReportResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetShowtimeCheckInReportResponseValidationError) ErrorName() string {
+	return "GetShowtimeCheckInReportResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetShowtimeCheckInReportResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetShowtimeCheckInReportResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetShowtimeCheckInReportResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetShowtimeCheckInReportResponseValidationError{}
@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	NotificationService_SayHello_FullMethodName = "/notification_service.NotificationService/SayHello"
+	NotificationService_SayHello_FullMethodName                 = "/notification_service.NotificationService/SayHello"
+	NotificationService_GetDeliveryAttemptList_FullMethodName   = "/notification_service.NotificationService/GetDeliveryAttemptList"
+	NotificationService_CheckInTicket_FullMethodName            = "/notification_service.NotificationService/CheckInTicket"
+	NotificationService_GetShowtimeCheckInReport_FullMethodName = "/notification_service.NotificationService/GetShowtimeCheckInReport"
 )
 
 // NotificationServiceClient is the client API for NotificationService service.
@@ -27,6 +30,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NotificationServiceClient interface {
 	SayHello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloResponse, error)
+	GetDeliveryAttemptList(ctx context.Context, in *GetDeliveryAttemptListRequest, opts ...grpc.CallOption) (*GetDeliveryAttemptListResponse, error)
+	CheckInTicket(ctx context.Context, in *CheckInTicketRequest, opts ...grpc.CallOption) (*CheckInTicketResponse, error)
+	GetShowtimeCheckInReport(ctx context.Context, in *GetShowtimeCheckInReportRequest, opts ...grpc.CallOption) (*GetShowtimeCheckInReportResponse, error)
 }
 
 type notificationServiceClient struct {
@@ -47,11 +53,44 @@ func (c *notificationServiceClient) SayHello(ctx context.Context, in *HelloReque
 	return out, nil
 }
 
+func (c *notificationServiceClient) GetDeliveryAttemptList(ctx context.Context, in *GetDeliveryAttemptListRequest, opts ...grpc.CallOption) (*GetDeliveryAttemptListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeliveryAttemptListResponse)
+	err := c.cc.Invoke(ctx, NotificationService_GetDeliveryAttemptList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) CheckInTicket(ctx context.Context, in *CheckInTicketRequest, opts ...grpc.CallOption) (*CheckInTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckInTicketResponse)
+	err := c.cc.Invoke(ctx, NotificationService_CheckInTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) GetShowtimeCheckInReport(ctx context.Context, in *GetShowtimeCheckInReportRequest, opts ...grpc.CallOption) (*GetShowtimeCheckInReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShowtimeCheckInReportResponse)
+	err := c.cc.Invoke(ctx, NotificationService_GetShowtimeCheckInReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServiceServer is the server API for NotificationService service.
 // All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility
 type NotificationServiceServer interface {
 	SayHello(context.Context, *HelloRequest) (*HelloResponse, error)
+	GetDeliveryAttemptList(context.Context, *GetDeliveryAttemptListRequest) (*GetDeliveryAttemptListResponse, error)
+	CheckInTicket(context.Context, *CheckInTicketRequest) (*CheckInTicketResponse, error)
+	GetShowtimeCheckInReport(context.Context, *GetShowtimeCheckInReportRequest) (*GetShowtimeCheckInReportResponse, error)
 	mustEmbedUnimplementedNotificationServiceServer()
 }
 
@@ -62,6 +101,15 @@ type UnimplementedNotificationServiceServer struct {
 func (UnimplementedNotificationServiceServer) SayHello(context.Context, *HelloRequest) (*HelloResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SayHello not implemented")
 }
+func (UnimplementedNotificationServiceServer) GetDeliveryAttemptList(context.Context, *GetDeliveryAttemptListRequest) (*GetDeliveryAttemptListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeliveryAttemptList not implemented")
+}
+func (UnimplementedNotificationServiceServer) CheckInTicket(context.Context, *CheckInTicketRequest) (*CheckInTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInTicket not implemented")
+}
+func (UnimplementedNotificationServiceServer) GetShowtimeCheckInReport(context.Context, *GetShowtimeCheckInReportRequest) (*GetShowtimeCheckInReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShowtimeCheckInReport not implemented")
+}
 func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 
 // UnsafeNotificationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -93,6 +141,60 @@ func _NotificationService_SayHello_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_GetDeliveryAttemptList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryAttemptListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).GetDeliveryAttemptList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_GetDeliveryAttemptList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).GetDeliveryAttemptList(ctx, req.(*GetDeliveryAttemptListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_CheckInTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).CheckInTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_CheckInTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).CheckInTicket(ctx, req.(*CheckInTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_GetShowtimeCheckInReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShowtimeCheckInReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).GetShowtimeCheckInReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_GetShowtimeCheckInReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).GetShowtimeCheckInReport(ctx, req.(*GetShowtimeCheckInReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -104,6 +206,18 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SayHello",
 			Handler:    _NotificationService_SayHello_Handler,
 		},
+		{
+			MethodName: "GetDeliveryAttemptList",
+			Handler:    _NotificationService_GetDeliveryAttemptList_Handler,
+		},
+		{
+			MethodName: "CheckInTicket",
+			Handler:    _NotificationService_CheckInTicket_Handler,
+		},
+		{
+			MethodName: "GetShowtimeCheckInReport",
+			Handler:    _NotificationService_GetShowtimeCheckInReport_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notification_service/notification_service.proto",
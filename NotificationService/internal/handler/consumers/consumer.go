@@ -4,7 +4,6 @@ import (
 	"NotificationService/internal/dataaccess/kafka/consumer"
 	"NotificationService/internal/dataaccess/kafka/producer"
 	"context"
-	"encoding/json"
 
 	"go.uber.org/zap"
 )
@@ -16,6 +15,8 @@ type NotificationServiceKafkaConsumer interface {
 type notificationServiceKafkaConsumer struct {
 	notificationCreatedHandler  NotificationCreatedMessageHandler
 	paymentTransactionCompleted PaymentTransactionCompletedMessageHandler
+	eventQuarantineProducer     producer.EventQuarantineProducer
+	eventDeadLetterProducer     producer.EventDeadLetterProducer
 	kafkaConsumer               consumer.Consumer
 	logger                      *zap.Logger
 }
@@ -23,12 +24,16 @@ type notificationServiceKafkaConsumer struct {
 func NewNotificationServiceKafkaConsumer(
 	notificationCreatedHandler NotificationCreatedMessageHandler,
 	paymentTransactionCompleted PaymentTransactionCompletedMessageHandler,
+	eventQuarantineProducer producer.EventQuarantineProducer,
+	eventDeadLetterProducer producer.EventDeadLetterProducer,
 	kafkaConsumer consumer.Consumer,
 	logger *zap.Logger,
 ) NotificationServiceKafkaConsumer {
 	return &notificationServiceKafkaConsumer{
 		notificationCreatedHandler:  notificationCreatedHandler,
 		paymentTransactionCompleted: paymentTransactionCompleted,
+		eventQuarantineProducer:     eventQuarantineProducer,
+		eventDeadLetterProducer:     eventDeadLetterProducer,
 		kafkaConsumer:               kafkaConsumer,
 		logger:                      logger,
 	}
@@ -40,25 +45,28 @@ func (n notificationServiceKafkaConsumer) Start(ctx context.Context) error {
 		producer.TopicNameNotificationServiceNotificationCreated,
 		func(ctx context.Context, queueName string, payload []byte) error {
 			var event producer.NotificationCreated
-			if err := json.Unmarshal(payload, &event); err != nil {
-				return err
+			if err := decodeEvent(payload, notificationCreatedSchema, &event); err != nil {
+				return n.eventQuarantineProducer.Produce(ctx, queueName, "", payload, err)
 			}
 
 			return n.notificationCreatedHandler.Handle(ctx, event)
 		},
+		consumer.WithDeadLetterFunc(n.eventDeadLetterProducer.Produce),
 	)
 
-	// payment_transaction_completed
+	// payment_transaction_completed, handled in any order when PaymentService produces it without a key: creating
+	// the notification of a booking that already has one is a no-op.
 	n.kafkaConsumer.RegisterHandler(
 		TopicNamePaymentServicePaymentTransactionCompleted,
 		func(ctx context.Context, queueName string, payload []byte) error {
 			var event PaymentTransactionCompleted
-			if err := json.Unmarshal(payload, &event); err != nil {
-				return err
+			if err := decodeEvent(payload, paymentTransactionCompletedSchema, &event); err != nil {
+				return n.eventQuarantineProducer.Produce(ctx, queueName, "", payload, err)
 			}
 
 			return n.paymentTransactionCompleted.Handle(ctx, event)
 		},
+		consumer.WithDeadLetterFunc(n.eventDeadLetterProducer.Produce),
 	)
 
 	return n.kafkaConsumer.Start(ctx)
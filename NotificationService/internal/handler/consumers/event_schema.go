@@ -0,0 +1,67 @@
+package consumers
+
+import (
+	"NotificationService/internal/dataaccess/kafka/envelope"
+	"encoding/json"
+	"fmt"
+)
+
+// eventUpgrader converts the data of an event from its schema version to the next one.
+type eventUpgrader func(data []byte) ([]byte, error)
+
+// eventSchema describes which versions of an event type a handler understands. Events older than
+// minSchemaVersion are rejected, events between minSchemaVersion and currentSchemaVersion are upgraded
+// one version at a time before being decoded, events newer than currentSchemaVersion are rejected.
+type eventSchema struct {
+	eventType            string
+	minSchemaVersion     uint32
+	currentSchemaVersion uint32
+	upgraders            map[uint32]eventUpgrader
+}
+
+type validatableEvent interface {
+	Validate() error
+}
+
+// decodeEvent unwraps payload, negotiates its schema version and decodes the data into event.
+// Every returned error means the payload can never be handled and should be quarantined.
+func decodeEvent(payload []byte, schema eventSchema, event validatableEvent) error {
+	eventEnvelope, err := envelope.Unmarshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if !eventEnvelope.Legacy && eventEnvelope.Type != schema.eventType {
+		return fmt.Errorf("%w: unexpected event type %q", envelope.ErrInvalidEnvelope, eventEnvelope.Type)
+	}
+
+	if eventEnvelope.SchemaVersion < schema.minSchemaVersion ||
+		eventEnvelope.SchemaVersion > schema.currentSchemaVersion {
+		return fmt.Errorf(
+			"%w: %s version %d, supported versions are %d to %d",
+			envelope.ErrUnsupportedSchemaVersion,
+			schema.eventType,
+			eventEnvelope.SchemaVersion,
+			schema.minSchemaVersion,
+			schema.currentSchemaVersion,
+		)
+	}
+
+	data := eventEnvelope.Data
+	for version := eventEnvelope.SchemaVersion; version < schema.currentSchemaVersion; version++ {
+		upgrader, ok := schema.upgraders[version]
+		if !ok {
+			return fmt.Errorf("%w: no upgrader from %s version %d", envelope.ErrUnsupportedSchemaVersion, schema.eventType, version)
+		}
+
+		if data, err = upgrader(data); err != nil {
+			return fmt.Errorf("%w: failed to upgrade %s from version %d: %w", envelope.ErrInvalidEvent, schema.eventType, version, err)
+		}
+	}
+
+	if err := json.Unmarshal(data, event); err != nil {
+		return fmt.Errorf("%w: %w", envelope.ErrInvalidEvent, err)
+	}
+
+	return event.Validate()
+}
@@ -1,13 +1,39 @@
 package consumers
 
 import (
+	"NotificationService/internal/dataaccess/kafka/consumer"
 	"NotificationService/internal/dataaccess/kafka/producer"
 	"NotificationService/internal/logic"
+	"NotificationService/internal/utils"
 	"context"
+	"encoding/json"
+	"errors"
 
 	"go.uber.org/zap"
 )
 
+var notificationCreatedSchema = eventSchema{
+	eventType:            producer.EventTypeNotificationCreated,
+	minSchemaVersion:     1,
+	currentSchemaVersion: producer.SchemaVersionNotificationCreated,
+	upgraders: map[uint32]eventUpgrader{
+		1: upgradeNotificationCreatedFromVersion1,
+	},
+}
+
+// upgradeNotificationCreatedFromVersion1 moves the booking id that version 1 events carried in id to
+// of_booking_id. The notification id is left unset and is looked up by booking when the event is handled.
+func upgradeNotificationCreatedFromVersion1(data []byte) ([]byte, error) {
+	var event struct {
+		ID uint32 `json:"id"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(producer.NotificationCreated{OfBookingId: event.ID})
+}
+
 type NotificationCreatedMessageHandler interface {
 	Handle(ctx context.Context, event producer.NotificationCreated) error
 }
@@ -28,10 +54,33 @@ func NewNotificationCreatedMessageHandler(
 }
 
 func (n notificationCreatedMessageHandler) Handle(ctx context.Context, event producer.NotificationCreated) error {
-	logger := n.logger.With(zap.Any("event", event))
+	ctx = utils.ContextWithBookingID(ctx, event.OfBookingId)
+
+	notificationId := event.ID
+	if notificationId == 0 {
+		var err error
+		if notificationId, err = n.notificationLogic.GetNotificationIdOfBooking(ctx, event.OfBookingId); err != nil {
+			utils.LoggerWithContext(ctx, n.logger).With(zap.Error(err)).Error("failed to get notification of booking")
+			return err
+		}
+	}
+
+	ctx = utils.ContextWithNotificationID(ctx, notificationId)
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Any("event", event))
 	logger.Info("notification created event received")
 
-	if err := n.notificationLogic.GeneratePDFAndSendEmail(ctx, event.ID); err != nil {
+	err := n.notificationLogic.GeneratePDFAndSendEmail(ctx, notificationId)
+	if errors.Is(err, logic.ErrNotificationDeferred) {
+		// The notification scheduler enqueues it again once its retry is due.
+		logger.With(zap.Error(err)).Warn("notification deferred, will retry it later")
+		return nil
+	}
+	if errors.Is(err, logic.ErrNotificationFailed) {
+		// Handling the event again finds the notification failed, it is parked instead of being retried.
+		logger.With(zap.Error(err)).Error("notification failed")
+		return consumer.Permanent(err)
+	}
+	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to handle notification created event")
 		return err
 	}
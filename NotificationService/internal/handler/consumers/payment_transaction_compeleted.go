@@ -1,22 +1,47 @@
 package consumers
 
 import (
+	"NotificationService/internal/dataaccess/kafka/envelope"
 	"NotificationService/internal/generated/payment_service"
 	"NotificationService/internal/logic"
+	"NotificationService/internal/utils"
 	"context"
+	"errors"
+	"fmt"
 
 	"go.uber.org/zap"
 )
 
 const (
 	TopicNamePaymentServicePaymentTransactionCompleted = "payment_service_payment_transaction_completed"
+
+	EventTypePaymentTransactionCompleted     = "payment_service.payment_transaction_completed"
+	SchemaVersionPaymentTransactionCompleted = 1
 )
 
+var paymentTransactionCompletedSchema = eventSchema{
+	eventType:            EventTypePaymentTransactionCompleted,
+	minSchemaVersion:     1,
+	currentSchemaVersion: SchemaVersionPaymentTransactionCompleted,
+}
+
 type PaymentTransactionCompleted struct {
 	OfBookingId              uint32                                          `json:"ofBookingId"`
 	PaymentTransactionStatus payment_service.PaymentTransactionStatus_Values `json:"paymentTransactionStatus"`
 }
 
+func (p PaymentTransactionCompleted) Validate() error {
+	if p.OfBookingId == 0 {
+		return fmt.Errorf("%w: missing ofBookingId", envelope.ErrInvalidEvent)
+	}
+
+	if _, ok := payment_service.PaymentTransactionStatus_Values_name[int32(p.PaymentTransactionStatus)]; !ok {
+		return fmt.Errorf("%w: unknown paymentTransactionStatus %d", envelope.ErrInvalidEvent, p.PaymentTransactionStatus)
+	}
+
+	return nil
+}
+
 type PaymentTransactionCompletedMessageHandler interface {
 	Handle(ctx context.Context, event PaymentTransactionCompleted) error
 }
@@ -37,10 +62,17 @@ func NewPaymentTransactionCompletedMessageHandler(
 }
 
 func (p paymentTransactionCompletedMessageHandler) Handle(ctx context.Context, event PaymentTransactionCompleted) error {
-	logger := p.logger.With(zap.Any("event", event))
+	ctx = utils.ContextWithBookingID(ctx, event.OfBookingId)
+	logger := utils.LoggerWithContext(ctx, p.logger).With(zap.Any("event", event))
 	logger.Info("payment transaction completed event received")
 
-	if err := p.notificationLogic.CreateNotification(ctx, event.OfBookingId); err != nil {
+	err := p.notificationLogic.CreateNotification(ctx, event.OfBookingId)
+	if errors.Is(err, logic.ErrNotificationAlreadyExists) {
+		// The event was delivered again, or the notification was created by the reconciliation.
+		logger.With(zap.Error(err)).Info("notification already created")
+		return nil
+	}
+	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to handle payment transaction completed event")
 		return err
 	}
@@ -0,0 +1,176 @@
+package consumers
+
+import (
+	"NotificationService/internal/dataaccess/kafka/consumer"
+	"NotificationService/internal/dataaccess/kafka/producer"
+	"NotificationService/internal/logic"
+	"NotificationService/internal/utils"
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+type ReplayOptions struct {
+	// BookingId restricts the replay to the events of one booking, 0 replays every event.
+	BookingId uint32
+	// DryRun only writes the events that would be replayed to Output, without handling them.
+	DryRun bool
+	Output io.Writer
+}
+
+type ReplayResult struct {
+	Read    uint64
+	Skipped uint64
+	Handled uint64
+	Failed  uint64
+}
+
+type NotificationServiceEventReplayer interface {
+	Replay(
+		ctx context.Context,
+		queueName string,
+		replayRange consumer.ReplayRange,
+		options ReplayOptions,
+	) (ReplayResult, error)
+}
+
+type notificationServiceEventReplayer struct {
+	notificationCreatedHandler  NotificationCreatedMessageHandler
+	paymentTransactionCompleted PaymentTransactionCompletedMessageHandler
+	notificationLogic           logic.NotificationLogic
+	kafkaReplayer               consumer.Replayer
+	logger                      *zap.Logger
+}
+
+func NewNotificationServiceEventReplayer(
+	notificationCreatedHandler NotificationCreatedMessageHandler,
+	paymentTransactionCompleted PaymentTransactionCompletedMessageHandler,
+	notificationLogic logic.NotificationLogic,
+	kafkaReplayer consumer.Replayer,
+	logger *zap.Logger,
+) NotificationServiceEventReplayer {
+	return &notificationServiceEventReplayer{
+		notificationCreatedHandler:  notificationCreatedHandler,
+		paymentTransactionCompleted: paymentTransactionCompleted,
+		notificationLogic:           notificationLogic,
+		kafkaReplayer:               kafkaReplayer,
+		logger:                      logger,
+	}
+}
+
+// replayedEvent is a decoded event together with the call that handles it again.
+type replayedEvent struct {
+	bookingId uint32
+	event     any
+	handle    func(ctx context.Context) error
+}
+
+func (n notificationServiceEventReplayer) Replay(
+	ctx context.Context,
+	queueName string,
+	replayRange consumer.ReplayRange,
+	options ReplayOptions,
+) (ReplayResult, error) {
+	decodeFunc, err := n.decodeFunc(queueName)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+
+	var read, skipped, handled, failed atomic.Uint64
+	err = n.kafkaReplayer.Replay(ctx, queueName, replayRange, func(ctx context.Context, queueName string, payload []byte) error {
+		read.Add(1)
+
+		event, err := decodeFunc(payload)
+		if err != nil {
+			failed.Add(1)
+			return err
+		}
+
+		if options.BookingId != 0 && event.bookingId != options.BookingId {
+			skipped.Add(1)
+			return nil
+		}
+
+		if options.DryRun {
+			skipped.Add(1)
+			fmt.Fprintf(options.Output, "would replay %s event of booking %d: %+v\n", queueName, event.bookingId, event.event)
+			return nil
+		}
+
+		if err := event.handle(ctx); err != nil {
+			failed.Add(1)
+			return err
+		}
+
+		handled.Add(1)
+		return nil
+	})
+
+	result := ReplayResult{
+		Read:    read.Load(),
+		Skipped: skipped.Load(),
+		Handled: handled.Load(),
+		Failed:  failed.Load(),
+	}
+	n.logger.
+		With(zap.String(utils.LogFieldQueueName, queueName)).
+		With(zap.Any("result", result)).
+		Info("replay completed")
+
+	return result, err
+}
+
+func (n notificationServiceEventReplayer) decodeFunc(queueName string) (func(payload []byte) (replayedEvent, error), error) {
+	switch queueName {
+	case producer.TopicNameNotificationServiceNotificationCreated:
+		return func(payload []byte) (replayedEvent, error) {
+			var event producer.NotificationCreated
+			if err := decodeEvent(payload, notificationCreatedSchema, &event); err != nil {
+				return replayedEvent{}, err
+			}
+
+			return replayedEvent{
+				bookingId: event.OfBookingId,
+				event:     event,
+				handle: func(ctx context.Context) error {
+					notificationId := event.ID
+					if notificationId == 0 {
+						var err error
+						if notificationId, err = n.notificationLogic.GetNotificationIdOfBooking(ctx, event.OfBookingId); err != nil {
+							return err
+						}
+					}
+
+					// The notification has usually been sent already, it has to be pending to be sent again.
+					if err := n.notificationLogic.ResetNotificationToPending(ctx, notificationId); err != nil {
+						return err
+					}
+
+					return n.notificationCreatedHandler.Handle(ctx, event)
+				},
+			}, nil
+		}, nil
+
+	case TopicNamePaymentServicePaymentTransactionCompleted:
+		return func(payload []byte) (replayedEvent, error) {
+			var event PaymentTransactionCompleted
+			if err := decodeEvent(payload, paymentTransactionCompletedSchema, &event); err != nil {
+				return replayedEvent{}, err
+			}
+
+			return replayedEvent{
+				bookingId: event.OfBookingId,
+				event:     event,
+				handle: func(ctx context.Context) error {
+					return n.paymentTransactionCompleted.Handle(ctx, event)
+				},
+			}, nil
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("replay is not supported for queue %s", queueName)
+	}
+}
@@ -6,4 +6,5 @@ var WireSet = wire.NewSet(
 	NewNotificationCreatedMessageHandler,
 	NewPaymentTransactionCompletedMessageHandler,
 	NewNotificationServiceKafkaConsumer,
+	NewNotificationServiceEventReplayer,
 )
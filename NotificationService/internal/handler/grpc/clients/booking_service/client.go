@@ -3,7 +3,9 @@ package userservice
 import (
 	"NotificationService/internal/configs"
 	"NotificationService/internal/generated/booking_service"
+	"NotificationService/internal/handler/grpc/clients/resilience"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -11,10 +13,12 @@ import (
 
 func NewClient(
 	config configs.BookingServiceClient,
+	tracerProvider trace.TracerProvider,
 	logger *zap.Logger,
 ) (booking_service.BookingServiceClient, error) {
-	var opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 	logger = logger.Named("BookingServiceClient")
+	var opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts = append(opts, resilience.NewDialOptions("booking_service", config.GRPCClientPolicy, tracerProvider, logger)...)
 
 	hosts := config.Addresses
 	clients := make([]booking_service.BookingServiceClient, 0, len(hosts))
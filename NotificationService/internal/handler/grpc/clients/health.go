@@ -0,0 +1,99 @@
+package clients
+
+import (
+	"NotificationService/internal/configs"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthChecker checks that the downstream services answer. It uses connections of its own, so that health
+// checks neither go through nor trip the circuit breakers of the clients.
+type HealthChecker interface {
+	ServiceNameList() []string
+	// Check succeeds if any address of the service reports serving. A service that does not implement the
+	// gRPC health service is considered healthy as long as it answers.
+	Check(ctx context.Context, serviceName string) error
+}
+
+type healthChecker struct {
+	serviceNameList []string
+	serviceConnMap  map[string][]*grpc.ClientConn
+}
+
+func NewHealthChecker(
+	userServiceClientConfig configs.UserServiceClient,
+	movieServiceClientConfig configs.MovieServiceClient,
+	bookingServiceClientConfig configs.BookingServiceClient,
+	logger *zap.Logger,
+) (HealthChecker, func(), error) {
+	checker := &healthChecker{
+		serviceConnMap: make(map[string][]*grpc.ClientConn),
+	}
+	cleanup := func() {
+		for _, connList := range checker.serviceConnMap {
+			for _, conn := range connList {
+				conn.Close()
+			}
+		}
+	}
+
+	serviceList := []struct {
+		serviceName string
+		addresses   []string
+	}{
+		{serviceName: "user_service", addresses: userServiceClientConfig.Addresses},
+		{serviceName: "movie_service", addresses: movieServiceClientConfig.Addresses},
+		{serviceName: "booking_service", addresses: bookingServiceClientConfig.Addresses},
+	}
+	for _, service := range serviceList {
+		for _, address := range service.addresses {
+			conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				logger.With(zap.Error(err), zap.String("address", address)).Error("failed to dial host")
+				cleanup()
+				return nil, nil, err
+			}
+
+			checker.serviceConnMap[service.serviceName] = append(checker.serviceConnMap[service.serviceName], conn)
+		}
+		checker.serviceNameList = append(checker.serviceNameList, service.serviceName)
+	}
+
+	return checker, cleanup, nil
+}
+
+func (h healthChecker) ServiceNameList() []string {
+	return h.serviceNameList
+}
+
+func (h healthChecker) Check(ctx context.Context, serviceName string) error {
+	connList := h.serviceConnMap[serviceName]
+	if len(connList) == 0 {
+		return fmt.Errorf("no address configured for %s", serviceName)
+	}
+
+	var errList []error
+	for _, conn := range connList {
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			return nil
+		case err != nil:
+			errList = append(errList, fmt.Errorf("%s: %w", conn.Target(), err))
+		case resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING:
+			errList = append(errList, fmt.Errorf("%s: %s", conn.Target(), resp.GetStatus()))
+		default:
+			return nil
+		}
+	}
+
+	return errors.Join(errList...)
+}
@@ -0,0 +1,104 @@
+package userservice
+
+import (
+	"NotificationService/internal/dataaccess/cache"
+	"NotificationService/internal/generated/movie_service"
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	cacheNamespaceShowtimeMetadata = "movie_service:showtime_metadata"
+	cacheNamespaceSeat             = "movie_service:seat"
+	defaultCacheTTL                = 5 * time.Minute
+)
+
+// cachedClient serves showtime metadata and seats from the cache, since they are shared by every booking
+// of a showtime and rarely change. Other calls go straight to MovieService.
+type cachedClient struct {
+	movie_service.MovieServiceClient
+	cacheClient cache.Client
+	ttl         time.Duration
+}
+
+func newCachedClient(
+	client movie_service.MovieServiceClient,
+	cacheClient cache.Client,
+	ttl time.Duration,
+) movie_service.MovieServiceClient {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+
+	return &cachedClient{
+		MovieServiceClient: client,
+		cacheClient:        cacheClient,
+		ttl:                ttl,
+	}
+}
+
+func (c cachedClient) GetShowtimeMetadata(
+	ctx context.Context,
+	in *movie_service.GetShowtimeMetadataRequest,
+	opts ...grpc.CallOption,
+) (*movie_service.GetShowtimeMetadataResponse, error) {
+	data, err := c.cacheClient.GetOrLoad(
+		ctx,
+		cacheNamespaceShowtimeMetadata,
+		fmt.Sprint(in.GetShowtimeId()),
+		c.ttl,
+		func(ctx context.Context) ([]byte, error) {
+			response, err := c.MovieServiceClient.GetShowtimeMetadata(ctx, in, opts...)
+			if err != nil {
+				return nil, err
+			}
+
+			return proto.Marshal(response)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &movie_service.GetShowtimeMetadataResponse{}
+	if err := proto.Unmarshal(data, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c cachedClient) GetSeat(
+	ctx context.Context,
+	in *movie_service.GetSeatRequest,
+	opts ...grpc.CallOption,
+) (*movie_service.GetSeatResponse, error) {
+	data, err := c.cacheClient.GetOrLoad(
+		ctx,
+		cacheNamespaceSeat,
+		fmt.Sprint(in.GetSeatId()),
+		c.ttl,
+		func(ctx context.Context) ([]byte, error) {
+			response, err := c.MovieServiceClient.GetSeat(ctx, in, opts...)
+			if err != nil {
+				return nil, err
+			}
+
+			return proto.Marshal(response)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &movie_service.GetSeatResponse{}
+	if err := proto.Unmarshal(data, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
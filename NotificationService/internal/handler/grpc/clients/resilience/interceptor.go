@@ -0,0 +1,186 @@
+package resilience
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/metrics"
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTimeout                    = 5 * time.Second
+	defaultRetryMaxAttempts           = 3
+	defaultRetryInitialBackoff        = 100 * time.Millisecond
+	defaultRetryJitterFraction        = 0.2
+	defaultBreakerConsecutiveFailures = 5
+	defaultBreakerHalfOpenMaxRequests = 1
+	defaultBreakerInterval            = time.Minute
+	defaultBreakerOpenTimeout         = 30 * time.Second
+)
+
+var (
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+
+	// retriableCodes are the codes on which a call is retried and counted as a failure by the circuit breaker.
+	// Every call made through the downstream clients is an idempotent read, so retrying them is safe.
+	retriableCodes = []codes.Code{codes.Unavailable, codes.DeadlineExceeded}
+)
+
+// IsCircuitOpen reports whether err was returned because the circuit breaker of a downstream service is open.
+func IsCircuitOpen(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
+
+// NewDialOptions returns the dial options applying the circuit breaker, retry and per-call timeout
+// policy to every unary call made through the client connection of the named service, and recording
+// its duration. Every attempt is traced as a client span carrying the trace context and the request id
+// to the service.
+func NewDialOptions(
+	serviceName string,
+	policy configs.GRPCClientPolicy,
+	tracerProvider trace.TracerProvider,
+	logger *zap.Logger,
+) []grpc.DialOption {
+	policy = withDefaults(policy)
+
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(tracerProvider))),
+		grpc.WithChainUnaryInterceptor(
+			utils.RequestIDUnaryClientInterceptor,
+			newMetricsInterceptor(serviceName),
+			newCircuitBreakerInterceptor(serviceName, policy.CircuitBreaker, logger),
+			retry.UnaryClientInterceptor(
+				retry.WithMax(policy.Retry.MaxAttempts),
+				retry.WithCodes(retriableCodes...),
+				retry.WithPerRetryTimeout(policy.Timeout),
+				retry.WithBackoff(retry.BackoffExponentialWithJitter(
+					policy.Retry.InitialBackoff,
+					policy.Retry.JitterFraction,
+				)),
+				retry.WithOnRetryCallback(func(ctx context.Context, attempt uint, err error) {
+					logger.With(zap.Uint("attempt", attempt)).With(zap.Error(err)).Warn("downstream call failed, retrying")
+				}),
+			),
+		),
+	}
+}
+
+// newMetricsInterceptor records the duration of every call, retries and time spent waiting on the circuit
+// breaker included.
+func newMetricsInterceptor(serviceName string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		metrics.DownstreamRPCDuration.
+			WithLabelValues(serviceName, method, status.Code(err).String()).
+			Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+func newCircuitBreakerInterceptor(
+	serviceName string,
+	config configs.GRPCClientCircuitBreaker,
+	logger *zap.Logger,
+) grpc.UnaryClientInterceptor {
+	breaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        serviceName,
+		MaxRequests: config.HalfOpenMaxRequests,
+		Interval:    config.Interval,
+		Timeout:     config.OpenTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= config.ConsecutiveFailures
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			logger.
+				With(zap.String("from", from.String())).
+				With(zap.String("to", to.String())).
+				Warn("circuit breaker state changed")
+		},
+		IsSuccessful: func(err error) bool {
+			return !isRetriable(err)
+		},
+	})
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		_, err := breaker.Execute(func() (any, error) {
+			return nil, invoker(ctx, method, req, reply, cc, opts...)
+		})
+		if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+			return fmt.Errorf("%w: %s %s", ErrCircuitOpen, serviceName, method)
+		}
+
+		return err
+	}
+}
+
+func isRetriable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errCode := status.Code(err)
+	for _, code := range retriableCodes {
+		if errCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withDefaults(policy configs.GRPCClientPolicy) configs.GRPCClientPolicy {
+	if policy.Timeout <= 0 {
+		policy.Timeout = defaultTimeout
+	}
+	if policy.Retry.MaxAttempts == 0 {
+		policy.Retry.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if policy.Retry.InitialBackoff <= 0 {
+		policy.Retry.InitialBackoff = defaultRetryInitialBackoff
+	}
+	if policy.Retry.JitterFraction <= 0 {
+		policy.Retry.JitterFraction = defaultRetryJitterFraction
+	}
+	if policy.CircuitBreaker.ConsecutiveFailures == 0 {
+		policy.CircuitBreaker.ConsecutiveFailures = defaultBreakerConsecutiveFailures
+	}
+	if policy.CircuitBreaker.HalfOpenMaxRequests == 0 {
+		policy.CircuitBreaker.HalfOpenMaxRequests = defaultBreakerHalfOpenMaxRequests
+	}
+	if policy.CircuitBreaker.Interval <= 0 {
+		policy.CircuitBreaker.Interval = defaultBreakerInterval
+	}
+	if policy.CircuitBreaker.OpenTimeout <= 0 {
+		policy.CircuitBreaker.OpenTimeout = defaultBreakerOpenTimeout
+	}
+
+	return policy
+}
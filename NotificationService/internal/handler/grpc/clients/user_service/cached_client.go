@@ -0,0 +1,71 @@
+package userservice
+
+import (
+	"NotificationService/internal/dataaccess/cache"
+	"NotificationService/internal/generated/user_service"
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	cacheNamespaceUser = "user_service:user"
+	defaultCacheTTL    = 5 * time.Minute
+)
+
+// cachedClient serves users from the cache. Other calls go straight to UserService.
+type cachedClient struct {
+	user_service.UserServiceClient
+	cacheClient cache.Client
+	ttl         time.Duration
+}
+
+func newCachedClient(
+	client user_service.UserServiceClient,
+	cacheClient cache.Client,
+	ttl time.Duration,
+) user_service.UserServiceClient {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+
+	return &cachedClient{
+		UserServiceClient: client,
+		cacheClient:       cacheClient,
+		ttl:               ttl,
+	}
+}
+
+func (c cachedClient) GetUser(
+	ctx context.Context,
+	in *user_service.GetUserRequest,
+	opts ...grpc.CallOption,
+) (*user_service.GetUserResponse, error) {
+	data, err := c.cacheClient.GetOrLoad(
+		ctx,
+		cacheNamespaceUser,
+		fmt.Sprint(in.GetId()),
+		c.ttl,
+		func(ctx context.Context) ([]byte, error) {
+			response, err := c.UserServiceClient.GetUser(ctx, in, opts...)
+			if err != nil {
+				return nil, err
+			}
+
+			return proto.Marshal(response)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &user_service.GetUserResponse{}
+	if err := proto.Unmarshal(data, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -2,8 +2,11 @@ package userservice
 
 import (
 	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/cache"
 	"NotificationService/internal/generated/user_service"
+	"NotificationService/internal/handler/grpc/clients/resilience"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -11,10 +14,13 @@ import (
 
 func NewClient(
 	config configs.UserServiceClient,
+	cacheClient cache.Client,
+	tracerProvider trace.TracerProvider,
 	logger *zap.Logger,
 ) (user_service.UserServiceClient, error) {
-	var opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 	logger = logger.Named("UserServiceClient")
+	var opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts = append(opts, resilience.NewDialOptions("user_service", config.GRPCClientPolicy, tracerProvider, logger)...)
 
 	hosts := config.Addresses
 	clients := make([]user_service.UserServiceClient, 0, len(hosts))
@@ -28,5 +34,5 @@ func NewClient(
 		clients = append(clients, client)
 	}
 
-	return clients[0], nil
+	return newCachedClient(clients[0], cacheClient, config.CacheTTL), nil
 }
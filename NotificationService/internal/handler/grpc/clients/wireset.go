@@ -9,6 +9,7 @@ import (
 )
 
 var WireSet = wire.NewSet(
+	NewHealthChecker,
 	userserviceclient.WireSet,
 	movieserviceclient.WireSet,
 	bookingserserviceclient.WireSet,
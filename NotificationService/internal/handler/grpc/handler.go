@@ -1,21 +1,32 @@
 package grpc
 
 import (
+	"NotificationService/internal/dataaccess/database"
 	pb "NotificationService/internal/generated/notification_service"
 	"NotificationService/internal/logic"
 	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Handler struct {
 	pb.UnimplementedNotificationServiceServer
-	notificationLogic logic.NotificationLogic
+	notificationLogic    logic.NotificationLogic
+	deliveryAttemptLogic logic.DeliveryAttemptLogic
+	checkInLogic         logic.CheckInLogic
 }
 
 func NewHandler(
 	notificationLogic logic.NotificationLogic,
+	deliveryAttemptLogic logic.DeliveryAttemptLogic,
+	checkInLogic logic.CheckInLogic,
 ) (pb.NotificationServiceServer, error) {
 	return &Handler{
-		notificationLogic: notificationLogic,
+		notificationLogic:    notificationLogic,
+		deliveryAttemptLogic: deliveryAttemptLogic,
+		checkInLogic:         checkInLogic,
 	}, nil
 }
 
@@ -29,3 +40,113 @@ func (h *Handler) SayHello(ctx context.Context, in *pb.HelloRequest) (*pb.HelloR
 		Message: "ngu",
 	}, nil
 }
+
+func (h *Handler) GetDeliveryAttemptList(
+	ctx context.Context,
+	in *pb.GetDeliveryAttemptListRequest,
+) (*pb.GetDeliveryAttemptListResponse, error) {
+	deliveryAttemptList, err := h.deliveryAttemptLogic.GetDeliveryAttemptList(ctx, in.GetBookingId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetDeliveryAttemptListResponse{
+		DeliveryAttemptList: make([]*pb.DeliveryAttempt, 0, len(deliveryAttemptList)),
+	}
+	for _, deliveryAttempt := range deliveryAttemptList {
+		resp.DeliveryAttemptList = append(resp.DeliveryAttemptList, &pb.DeliveryAttempt{
+			Id:               deliveryAttempt.ID,
+			OfNotificationId: deliveryAttempt.OfNotificationId,
+			OfBookingId:      deliveryAttempt.OfBookingId,
+			Channel:          deliveryAttempt.Channel,
+			Recipient:        deliveryAttempt.Recipient,
+			Status:           toPBDeliveryAttemptStatus(deliveryAttempt.Status),
+			MessageId:        deliveryAttempt.MessageID,
+			TemplateVersion:  deliveryAttempt.TemplateVersion,
+			ErrorClass:       deliveryAttempt.ErrorClass,
+			ProviderResponse: deliveryAttempt.ProviderResponse,
+			StartedAt:        uint64(deliveryAttempt.StartedAt.UnixMilli()),
+			FinishedAt:       uint64(deliveryAttempt.FinishedAt.UnixMilli()),
+		})
+	}
+
+	return resp, nil
+}
+
+func (h *Handler) CheckInTicket(ctx context.Context, in *pb.CheckInTicketRequest) (*pb.CheckInTicketResponse, error) {
+	if in.GetTicketToken() == "" || in.GetGate() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ticket_token and gate are required")
+	}
+
+	// The staff id in the request is ignored, the check-in is attributed to the authenticated caller.
+	staffId, ok := StaffIdFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "staff is not authenticated")
+	}
+
+	ticketCheckIn, err := h.checkInLogic.CheckInTicket(ctx, logic.CheckInTicketParams{
+		TicketToken: in.GetTicketToken(),
+		Gate:        in.GetGate(),
+		StaffId:     staffId,
+	})
+	switch {
+	case errors.Is(err, logic.ErrInvalidTicket):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, logic.ErrTicketAlreadyCheckedIn):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, logic.ErrBookingNotConfirmed), errors.Is(err, logic.ErrOutsideCheckInWindow):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	return &pb.CheckInTicketResponse{
+		TicketCheckIn: toPBTicketCheckIn(ticketCheckIn),
+	}, nil
+}
+
+func (h *Handler) GetShowtimeCheckInReport(
+	ctx context.Context,
+	in *pb.GetShowtimeCheckInReportRequest,
+) (*pb.GetShowtimeCheckInReportResponse, error) {
+	report, err := h.checkInLogic.GetShowtimeCheckInReport(ctx, in.GetShowtimeId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetShowtimeCheckInReportResponse{
+		ShowtimeId:                report.ShowtimeId,
+		ConfirmedBookingCount:     uint32(report.ConfirmedBookingCount),
+		CheckedInCount:            uint32(len(report.TicketCheckInList)),
+		NotCheckedInBookingIdList: report.NotCheckedInBookingIdList,
+		TicketCheckInList:         make([]*pb.TicketCheckIn, 0, len(report.TicketCheckInList)),
+	}
+	for _, ticketCheckIn := range report.TicketCheckInList {
+		resp.TicketCheckInList = append(resp.TicketCheckInList, toPBTicketCheckIn(ticketCheckIn))
+	}
+
+	return resp, nil
+}
+
+func toPBTicketCheckIn(ticketCheckIn *database.TicketCheckIn) *pb.TicketCheckIn {
+	return &pb.TicketCheckIn{
+		Id:           ticketCheckIn.ID,
+		OfBookingId:  ticketCheckIn.OfBookingId,
+		OfShowtimeId: ticketCheckIn.OfShowtimeId,
+		OfSeatId:     ticketCheckIn.OfSeatId,
+		Gate:         ticketCheckIn.Gate,
+		StaffId:      ticketCheckIn.StaffId,
+		CheckedInAt:  uint64(ticketCheckIn.CheckedInAt.UnixMilli()),
+	}
+}
+
+func toPBDeliveryAttemptStatus(status database.DeliveryAttemptStatus) pb.DeliveryAttemptStatus {
+	switch status {
+	case database.DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_SUCCESS:
+		return pb.DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_SUCCESS
+	case database.DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_FAILED:
+		return pb.DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_FAILED
+	default:
+		return pb.DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_UNSPECIFIED
+	}
+}
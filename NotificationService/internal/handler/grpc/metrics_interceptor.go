@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"NotificationService/internal/metrics"
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func metricsUnaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeHandled(info.FullMethod, start, err)
+
+	return resp, err
+}
+
+func metricsStreamServerInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeHandled(info.FullMethod, start, err)
+
+	return err
+}
+
+func observeHandled(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	metrics.GRPCServerHandlingDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+	metrics.GRPCServerHandled.WithLabelValues(method, code).Inc()
+}
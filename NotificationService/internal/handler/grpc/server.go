@@ -3,31 +3,70 @@ package grpc
 import (
 	"NotificationService/internal/configs"
 	pb "NotificationService/internal/generated/notification_service"
+	"NotificationService/internal/logic"
+	"NotificationService/internal/utils"
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/validator"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthUpdateInterval = 5 * time.Second
 )
 
 type Server interface {
 	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
 }
 
 func NewServer(
 	grpcConfig configs.GRPC,
 	handler pb.NotificationServiceServer,
+	healthLogic logic.HealthLogic,
+	tracerProvider trace.TracerProvider,
+	staffAuthenticator StaffAuthenticator,
 ) Server {
+	var opts = []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(tracerProvider))),
+		grpc.ChainUnaryInterceptor(
+			utils.RequestIDUnaryServerInterceptor,
+			metricsUnaryServerInterceptor,
+			staffAuthenticator.UnaryServerInterceptor,
+			validator.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			utils.RequestIDStreamServerInterceptor,
+			metricsStreamServerInterceptor,
+			validator.StreamServerInterceptor(),
+		),
+	}
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterNotificationServiceServer(grpcServer, handler)
+
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+
 	return &server{
-		grpcConfig: grpcConfig,
-		handler:    handler,
+		grpcConfig:   grpcConfig,
+		grpcServer:   grpcServer,
+		healthServer: healthServer,
+		healthLogic:  healthLogic,
 	}
 }
 
 type server struct {
-	grpcConfig configs.GRPC
-	handler    pb.NotificationServiceServer
+	grpcConfig   configs.GRPC
+	grpcServer   *grpc.Server
+	healthServer *health.Server
+	healthLogic  logic.HealthLogic
 }
 
 func (s *server) Start(ctx context.Context) error {
@@ -37,17 +76,49 @@ func (s *server) Start(ctx context.Context) error {
 	}
 	defer listener.Close()
 
-	var opts = []grpc.ServerOption{
-		grpc.ChainUnaryInterceptor(
-			validator.UnaryServerInterceptor(),
-		),
-		grpc.ChainStreamInterceptor(
-			validator.StreamServerInterceptor(),
-		),
-	}
-	server := grpc.NewServer(opts...)
-	pb.RegisterNotificationServiceServer(server, s.handler)
+	go s.updateHealth(ctx)
 
 	fmt.Printf("gRPC server is running on %s\n", s.grpcConfig.Address)
-	return server.Serve(listener)
+	return s.grpcServer.Serve(listener)
+}
+
+// updateHealth reports the readiness of the service through the grpc.health.v1 service until ctx is done.
+func (s *server) updateHealth(ctx context.Context) {
+	ticker := time.NewTicker(healthUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		servingStatus := grpc_health_v1.HealthCheckResponse_SERVING
+		if !s.healthLogic.Ready(ctx).Healthy() {
+			servingStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		s.healthServer.SetServingStatus("", servingStatus)
+		s.healthServer.SetServingStatus(pb.NotificationService_ServiceDesc.ServiceName, servingStatus)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop waits for in-flight RPCs to finish, forcefully closing the remaining connections once ctx is done.
+func (s *server) Stop(ctx context.Context) error {
+	// Callers watching the health service stop sending requests before the server goes away.
+	s.healthServer.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
 }
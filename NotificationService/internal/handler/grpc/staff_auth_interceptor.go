@@ -0,0 +1,145 @@
+package grpc
+
+import (
+	"NotificationService/internal/configs"
+	pb "NotificationService/internal/generated/notification_service"
+	"NotificationService/internal/generated/user_service"
+	"NotificationService/internal/utils"
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationMetadataKey = "authorization"
+	bearerTokenPrefix        = "Bearer "
+
+	defaultCheckInStaffPermission = "tickets.check_in"
+	defaultSupportPermission      = "notifications.support"
+)
+
+type staffIdContextKey struct{}
+
+// StaffIdFromContext returns the id of the staff member authenticated by the StaffAuthenticator.
+func StaffIdFromContext(ctx context.Context) (uint32, bool) {
+	staffId, ok := ctx.Value(staffIdContextKey{}).(uint32)
+	return staffId, ok
+}
+
+// StaffAuthenticator resolves the caller of a staff-only RPC from the bearer token in its metadata through
+// UserService, and rejects callers without the permission of the RPC: the check-in permission for the check-in
+// RPCs, the support permission for the delivery attempts, which carry email addresses and SMTP responses.
+type StaffAuthenticator interface {
+	UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error)
+}
+
+type staffAuthenticator struct {
+	userServiceClient user_service.UserServiceClient
+	// methodToPermissionMap maps the staff-only RPCs to the permission their caller must hold.
+	methodToPermissionMap map[string]string
+	logger                *zap.Logger
+}
+
+func NewStaffAuthenticator(
+	grpcConfig configs.GRPC,
+	checkInConfig configs.CheckIn,
+	userServiceClient user_service.UserServiceClient,
+	logger *zap.Logger,
+) StaffAuthenticator {
+	staffPermission := checkInConfig.StaffPermission
+	if staffPermission == "" {
+		staffPermission = defaultCheckInStaffPermission
+	}
+
+	supportPermission := grpcConfig.SupportPermission
+	if supportPermission == "" {
+		supportPermission = defaultSupportPermission
+	}
+
+	return &staffAuthenticator{
+		userServiceClient: userServiceClient,
+		methodToPermissionMap: map[string]string{
+			pb.NotificationService_CheckInTicket_FullMethodName:            staffPermission,
+			pb.NotificationService_GetShowtimeCheckInReport_FullMethodName: staffPermission,
+			pb.NotificationService_GetDeliveryAttemptList_FullMethodName:   supportPermission,
+		},
+		logger: logger,
+	}
+}
+
+func (s staffAuthenticator) UnaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	permission, ok := s.methodToPermissionMap[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	staffId, err := s.authenticateStaff(ctx, permission)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(context.WithValue(ctx, staffIdContextKey{}, staffId), req)
+}
+
+func (s staffAuthenticator) authenticateStaff(ctx context.Context, permission string) (uint32, error) {
+	logger := utils.LoggerWithContext(ctx, s.logger).With(zap.String("permission", permission))
+
+	token := bearerTokenFromMetadata(ctx)
+	if token == "" {
+		return 0, status.Error(codes.Unauthenticated, "a bearer token is required")
+	}
+
+	getUserFromTokenResponse, err := s.userServiceClient.GetUserFromToken(ctx, &user_service.GetUserFromTokenRequest{
+		Token: token,
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Info("failed to get user from token")
+		return 0, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	staffId := getUserFromTokenResponse.GetUser().GetId()
+	logger = logger.With(zap.Uint32("staff_id", staffId))
+
+	getUserPermissionListResponse, err := s.userServiceClient.GetUserPermissionListOfUser(
+		ctx,
+		&user_service.GetUserPermissionListOfUserRequest{UserId: staffId},
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get user permission list of user")
+		return 0, status.Error(codes.Internal, "failed to get user permission list")
+	}
+
+	for _, userPermission := range getUserPermissionListResponse.GetUserPermissionList() {
+		if userPermission.GetPermissionName() == permission {
+			return staffId, nil
+		}
+	}
+
+	logger.Info("user does not have the permission")
+	return 0, status.Errorf(codes.PermissionDenied, "user does not have the %s permission", permission)
+}
+
+func bearerTokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, authorization := range md.Get(authorizationMetadataKey) {
+		if token, found := strings.CutPrefix(authorization, bearerTokenPrefix); found {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return ""
+}
@@ -9,5 +9,6 @@ import (
 var WireSet = wire.NewSet(
 	NewServer,
 	NewHandler,
+	NewStaffAuthenticator,
 	clients.WireSet,
 )
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/logic"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const defaultAddress = ":9090"
+
+type Server interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+func NewServer(httpConfig configs.HTTP, healthLogic logic.HealthLogic) Server {
+	address := httpConfig.Address
+	if address == "" {
+		address = defaultAddress
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeHealthReport(w, healthLogic.Live(r.Context()))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		writeHealthReport(w, healthLogic.Ready(r.Context()))
+	})
+
+	return &server{
+		address: address,
+		httpServer: &http.Server{
+			Handler: mux,
+		},
+	}
+}
+
+type server struct {
+	address    string
+	httpServer *http.Server
+}
+
+func (s *server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("HTTP server is running on %s\n", s.address)
+	if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop waits for in-flight requests to finish, forcefully closing the remaining connections once ctx is done.
+func (s *server) Stop(ctx context.Context) error {
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.httpServer.Close()
+		return err
+	}
+
+	return nil
+}
+
+func writeHealthReport(w http.ResponseWriter, report logic.HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Healthy() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/logic"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultNotificationSchedulerInterval = 5 * time.Second
+)
+
+type NotificationSchedulerJob interface {
+	// Start enqueues the notifications that are due once per interval until ctx is cancelled.
+	Start(ctx context.Context) error
+}
+
+type notificationSchedulerJob struct {
+	notificationLogic logic.NotificationLogic
+	interval          time.Duration
+	logger            *zap.Logger
+}
+
+func NewNotificationSchedulerJob(
+	notificationLogic logic.NotificationLogic,
+	notificationSchedulerConfig configs.NotificationScheduler,
+	logger *zap.Logger,
+) NotificationSchedulerJob {
+	interval := notificationSchedulerConfig.Interval
+	if interval <= 0 {
+		interval = defaultNotificationSchedulerInterval
+	}
+
+	return &notificationSchedulerJob{
+		notificationLogic: notificationLogic,
+		interval:          interval,
+		logger:            logger,
+	}
+}
+
+func (n notificationSchedulerJob) Start(ctx context.Context) error {
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			enqueuedCount, err := n.notificationLogic.EnqueueDueNotifications(ctx)
+			if err != nil {
+				n.logger.With(zap.Error(err)).Error("failed to enqueue due notifications")
+				continue
+			}
+			if enqueuedCount > 0 {
+				n.logger.With(zap.Int("enqueued_count", enqueuedCount)).Info("enqueued due notifications")
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/logic"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReconciliationInterval = time.Hour
+)
+
+type ReconciliationJob interface {
+	// Start reconciles bookings and notifications once per interval until ctx is cancelled. A run is skipped
+	// while another replica reconciles.
+	Start(ctx context.Context) error
+	Enabled() bool
+}
+
+type reconciliationJob struct {
+	reconciliationLogic logic.ReconciliationLogic
+	enabled             bool
+	interval            time.Duration
+	logger              *zap.Logger
+}
+
+func NewReconciliationJob(
+	reconciliationLogic logic.ReconciliationLogic,
+	reconciliationConfig configs.Reconciliation,
+	logger *zap.Logger,
+) ReconciliationJob {
+	interval := reconciliationConfig.Interval
+	if interval <= 0 {
+		interval = defaultReconciliationInterval
+	}
+
+	return &reconciliationJob{
+		reconciliationLogic: reconciliationLogic,
+		enabled:             reconciliationConfig.Enabled,
+		interval:            interval,
+		logger:              logger,
+	}
+}
+
+func (r reconciliationJob) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			reconciled, err := r.reconciliationLogic.TryReconcile(ctx)
+			if err != nil {
+				r.logger.With(zap.Error(err)).Error("failed to reconcile notifications")
+				continue
+			}
+			if !reconciled {
+				r.logger.Info("reconciliation is run by another replica, skipping it")
+			}
+		}
+	}
+}
+
+func (r reconciliationJob) Enabled() bool {
+	return r.enabled
+}
@@ -0,0 +1,8 @@
+package jobs
+
+import "github.com/google/wire"
+
+var WireSet = wire.NewSet(
+	NewNotificationSchedulerJob,
+	NewReconciliationJob,
+)
@@ -0,0 +1,44 @@
+package pdfgenerator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Color struct {
+	R int
+	G int
+	B int
+}
+
+// ParseColor parses a color written as #RRGGBB.
+func ParseColor(value string) (Color, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) != 6 {
+		return Color{}, fmt.Errorf("invalid color %q, expected #RRGGBB", value)
+	}
+
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid color %q, expected #RRGGBB", value)
+	}
+
+	return Color{
+		R: int(rgb >> 16 & 0xFF),
+		G: int(rgb >> 8 & 0xFF),
+		B: int(rgb & 0xFF),
+	}, nil
+}
+
+// Branding customizes the invoices of a theater or a chain of theaters.
+type Branding struct {
+	// Layout is the name of the layout the invoice is rendered with, the default layout if empty.
+	Layout string
+	// Logo is a PNG or JPEG image, the logo block is skipped if it is empty.
+	Logo []byte
+	// PrimaryColor highlights the header and the order table, they are black if it is nil.
+	PrimaryColor *Color
+	// LegalFooter is printed at the bottom of the invoice, the legal footer block is skipped if it is empty.
+	LegalFooter string
+}
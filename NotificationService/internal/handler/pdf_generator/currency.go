@@ -0,0 +1,63 @@
+package pdfgenerator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/currency"
+)
+
+type numberLocale struct {
+	groupSeparator   string
+	decimalSeparator string
+}
+
+var localeToNumberLocale = map[string]numberLocale{
+	LocaleVietnamese: {groupSeparator: ".", decimalSeparator: ","},
+	LocaleEnglish:    {groupSeparator: ",", decimalSeparator: "."},
+}
+
+// minorUnitExponent returns the ISO 4217 code and minor unit exponent of currencyCode, amounts are stored in
+// minor units. Unknown codes are returned as is with an exponent of 0, so that their amounts are printed
+// unscaled.
+func minorUnitExponent(currencyCode string) (string, int) {
+	unit, err := currency.ParseISO(strings.TrimSpace(currencyCode))
+	if err != nil {
+		return currencyCode, 0
+	}
+
+	exponent, _ := currency.Standard.Rounding(unit)
+	return unit.String(), exponent
+}
+
+// formatAmount formats an amount in minor units of currencyCode with the separators of locale, followed by
+// the ISO 4217 code of the currency, e.g. 12000 "usd" as "120.00 USD" in English.
+func formatAmount(amount uint64, currencyCode string, locale string) string {
+	numberLocale, ok := localeToNumberLocale[locale]
+	if !ok {
+		numberLocale = localeToNumberLocale[defaultLocale]
+	}
+
+	code, exponent := minorUnitExponent(currencyCode)
+
+	digits := strconv.FormatUint(amount, 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	integerDigits, fractionDigits := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+
+	var builder strings.Builder
+	for i, digit := range integerDigits {
+		if i > 0 && (len(integerDigits)-i)%3 == 0 {
+			builder.WriteString(numberLocale.groupSeparator)
+		}
+		builder.WriteRune(digit)
+	}
+	if fractionDigits != "" {
+		builder.WriteString(numberLocale.decimalSeparator)
+		builder.WriteString(fractionDigits)
+	}
+
+	return fmt.Sprintf("%s %s", builder.String(), code)
+}
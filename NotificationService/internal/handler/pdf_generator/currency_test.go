@@ -0,0 +1,93 @@
+package pdfgenerator
+
+import "testing"
+
+func TestFormatAmount(t *testing.T) {
+	testCases := []struct {
+		name         string
+		amount       uint64
+		currencyCode string
+		locale       string
+		want         string
+	}{
+		{
+			name:         "cents in English",
+			amount:       12000,
+			currencyCode: "usd",
+			locale:       LocaleEnglish,
+			want:         "120.00 USD",
+		},
+		{
+			name:         "cents in Vietnamese",
+			amount:       12000,
+			currencyCode: "USD",
+			locale:       LocaleVietnamese,
+			want:         "120,00 USD",
+		},
+		{
+			name:         "grouped thousands",
+			amount:       123456789,
+			currencyCode: "EUR",
+			locale:       LocaleEnglish,
+			want:         "1,234,567.89 EUR",
+		},
+		{
+			name:         "amount below one major unit",
+			amount:       5,
+			currencyCode: "USD",
+			locale:       LocaleEnglish,
+			want:         "0.05 USD",
+		},
+		{
+			name:         "zero amount",
+			amount:       0,
+			currencyCode: "USD",
+			locale:       LocaleEnglish,
+			want:         "0.00 USD",
+		},
+		{
+			name:         "currency without minor unit",
+			amount:       120000,
+			currencyCode: "VND",
+			locale:       LocaleVietnamese,
+			want:         "120.000 VND",
+		},
+		{
+			name:         "currency with three decimals",
+			amount:       1234,
+			currencyCode: "KWD",
+			locale:       LocaleEnglish,
+			want:         "1.234 KWD",
+		},
+		{
+			name:         "code with surrounding spaces",
+			amount:       100,
+			currencyCode: " jpy ",
+			locale:       LocaleEnglish,
+			want:         "100 JPY",
+		},
+		{
+			name:         "unknown currency printed unscaled",
+			amount:       1000,
+			currencyCode: "points",
+			locale:       LocaleEnglish,
+			want:         "1,000 points",
+		},
+		{
+			name:         "unknown locale falls back to the default locale",
+			amount:       123456,
+			currencyCode: "USD",
+			locale:       "fr",
+			want:         "1.234,56 USD",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := formatAmount(testCase.amount, testCase.currencyCode, testCase.locale)
+			if got != testCase.want {
+				t.Errorf("formatAmount(%d, %q, %q) = %q, want %q", testCase.amount, testCase.currencyCode, testCase.locale, got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package pdfgenerator
+
+import "github.com/jung-kurt/gofpdf"
+
+// document draws text with the first font of its chain that can render it, so that callers only choose the
+// style and size of the font.
+type document struct {
+	*gofpdf.Fpdf
+	fonts     fontChain
+	fontStyle string
+	fontSize  float64
+}
+
+func newDocument(fonts fontChain) *document {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	fonts.register(pdf)
+
+	return &document{
+		Fpdf:     pdf,
+		fonts:    fonts,
+		fontSize: 10,
+	}
+}
+
+func (d *document) setFont(style string, size float64) {
+	d.fontStyle = style
+	d.fontSize = size
+}
+
+// useFontFor switches to the font used for text and returns text as it has to be drawn with it.
+func (d *document) useFontFor(text string) string {
+	family, text := d.fonts.fontFor(text)
+	d.SetFont(family, d.fontStyle, d.fontSize)
+	return text
+}
+
+func (d *document) cell(w, h float64, text string) {
+	d.Cell(w, h, d.useFontFor(text))
+}
+
+func (d *document) cellFormat(w, h float64, text string, border string, ln int, align string) {
+	d.CellFormat(w, h, d.useFontFor(text), border, ln, align, false, 0, "")
+}
+
+func (d *document) multiCell(w, h float64, text string, border string, align string) {
+	d.MultiCell(w, h, d.useFontFor(text), border, align, false)
+}
+
+// ensureSpace starts a new page unless h fits between the current position and the bottom margin, so that
+// a drawing is not split across pages.
+func (d *document) ensureSpace(h float64) {
+	_, pageHeight := d.GetPageSize()
+	_, bottomMargin := d.GetAutoPageBreak()
+	if d.GetY()+h <= pageHeight-bottomMargin {
+		return
+	}
+
+	d.AddPage()
+}
@@ -0,0 +1,219 @@
+package pdfgenerator
+
+import (
+	"NotificationService/internal/configs"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	fontStyleRegular = ""
+	fontStyleBold    = "B"
+	fontStyleItalic  = "I"
+
+	embeddedFontFamilyName  = "DejaVuSansCondensed"
+	missingGlyphReplacement = "?"
+	maxBMPRune              = 0xFFFF
+)
+
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	embeddedFontRegular []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	embeddedFontBold []byte
+	//go:embed fonts/DejaVuSansCondensed-Oblique.ttf
+	embeddedFontItalic []byte
+)
+
+// fontFamily is a UTF-8 TrueType font registered with every document.
+type fontFamily struct {
+	name        string
+	styleToData map[string][]byte
+	// styleToFont are the parsed fonts, drawn by the ticket card generator.
+	styleToFont map[string]*sfnt.Font
+	// regular is parsed to tell which characters the family has glyphs for, the other styles are assumed
+	// to cover the same characters.
+	regular *sfnt.Font
+}
+
+func newFontFamily(name string, regular []byte, bold []byte, italic []byte) (fontFamily, error) {
+	regularFont, err := sfnt.Parse(regular)
+	if err != nil {
+		return fontFamily{}, fmt.Errorf("failed to parse font %s: %w", name, err)
+	}
+
+	// Styles without a font of their own are rendered with the regular font.
+	if bold == nil {
+		bold = regular
+	}
+	if italic == nil {
+		italic = regular
+	}
+
+	styleToData := map[string][]byte{
+		fontStyleRegular: regular,
+		fontStyleBold:    bold,
+		fontStyleItalic:  italic,
+	}
+	styleToFont := map[string]*sfnt.Font{
+		fontStyleRegular: regularFont,
+	}
+	for _, style := range []string{fontStyleBold, fontStyleItalic} {
+		styleFont, err := sfnt.Parse(styleToData[style])
+		if err != nil {
+			return fontFamily{}, fmt.Errorf("failed to parse font %s: %w", name, err)
+		}
+		styleToFont[style] = styleFont
+	}
+
+	return fontFamily{
+		name:        name,
+		styleToData: styleToData,
+		styleToFont: styleToFont,
+		regular:     regularFont,
+	}, nil
+}
+
+func (f fontFamily) hasGlyph(buffer *sfnt.Buffer, r rune) bool {
+	// gofpdf only maps the characters of the Basic Multilingual Plane, e.g. not emojis.
+	if r > maxBMPRune {
+		return false
+	}
+
+	glyphIndex, err := f.regular.GlyphIndex(buffer, r)
+	return err == nil && glyphIndex != 0
+}
+
+func (f fontFamily) hasGlyphs(text string) bool {
+	var buffer sfnt.Buffer
+	for _, r := range text {
+		if !unicode.IsGraphic(r) || unicode.IsSpace(r) {
+			continue
+		}
+		if !f.hasGlyph(&buffer, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fontChain are the font families tried in order for each text, it always ends with the embedded font.
+type fontChain []fontFamily
+
+func newFontChain(fontFamilyConfigs []configs.PDFFontFamily) (fontChain, error) {
+	chain := make(fontChain, 0, len(fontFamilyConfigs)+1)
+	for i, fontFamilyConfig := range fontFamilyConfigs {
+		name := fontFamilyConfig.Name
+		if name == "" {
+			name = fmt.Sprintf("font%d", i)
+		}
+
+		regular, err := os.ReadFile(fontFamilyConfig.Regular)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read regular font of %s: %w", name, err)
+		}
+		bold, err := readOptionalFontFile(fontFamilyConfig.Bold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bold font of %s: %w", name, err)
+		}
+		italic, err := readOptionalFontFile(fontFamilyConfig.Italic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read italic font of %s: %w", name, err)
+		}
+
+		family, err := newFontFamily(name, regular, bold, italic)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, family)
+	}
+
+	embeddedFamily, err := newFontFamily(embeddedFontFamilyName, embeddedFontRegular, embeddedFontBold, embeddedFontItalic)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(chain, embeddedFamily), nil
+}
+
+func readOptionalFontFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	return os.ReadFile(path)
+}
+
+func (c fontChain) register(pdf *gofpdf.Fpdf) {
+	for _, family := range c {
+		for style, data := range family.styleToData {
+			pdf.AddUTF8FontFromBytes(family.name, style, data)
+		}
+	}
+}
+
+// fontFor returns the first family of the chain with glyphs for every character of text. If there is none,
+// text is rendered with the first family, its characters without glyphs being replaced by their closest
+// form without diacritics, or by a question mark.
+func (c fontChain) fontFor(text string) (string, string) {
+	family, text := c.familyFor(text)
+	return family.name, text
+}
+
+// familyFor is fontFor returning the family itself.
+func (c fontChain) familyFor(text string) (fontFamily, string) {
+	for _, family := range c {
+		if family.hasGlyphs(text) {
+			return family, text
+		}
+	}
+
+	var (
+		family  = c[0]
+		buffer  sfnt.Buffer
+		builder strings.Builder
+	)
+	for _, r := range text {
+		if !unicode.IsGraphic(r) || unicode.IsSpace(r) || family.hasGlyph(&buffer, r) {
+			builder.WriteRune(r)
+			continue
+		}
+
+		builder.WriteString(c.replaceMissingGlyph(family, &buffer, r))
+	}
+
+	return family, builder.String()
+}
+
+func (c fontChain) replaceMissingGlyph(family fontFamily, buffer *sfnt.Buffer, r rune) string {
+	switch r {
+	case 'đ':
+		r = 'd'
+	case 'Đ':
+		r = 'D'
+	}
+
+	var base strings.Builder
+	for _, decomposed := range norm.NFD.String(string(r)) {
+		if unicode.Is(unicode.Mn, decomposed) {
+			continue
+		}
+		if !family.hasGlyph(buffer, decomposed) {
+			return missingGlyphReplacement
+		}
+		base.WriteRune(decomposed)
+	}
+	if base.Len() == 0 {
+		return missingGlyphReplacement
+	}
+
+	return base.String()
+}
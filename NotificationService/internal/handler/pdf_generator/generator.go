@@ -1,169 +1,126 @@
 package pdfgenerator
 
 import (
+	"NotificationService/internal/utils"
 	"bytes"
-	"fmt"
+	"context"
 	"time"
 
-	"github.com/jung-kurt/gofpdf"
-	"github.com/skip2/go-qrcode"
 	"go.uber.org/zap"
 )
 
+// PDFTicket is a seat of the PDF, with the booking it was booked by.
+type PDFTicket struct {
+	BookingId uint32
+	SeatNo    string
+	Amount    uint64
+	// TicketToken is the signed token encoded in the QR code of the ticket, it is checked by the gate
+	// scanners.
+	TicketToken string
+}
+
 type PDFGenerateParams struct {
+	// BookingId is the booking the PDF is filed under, the first of the bookings of its tickets.
 	BookingId       uint32
 	Username        string
 	Email           string
-	Amount          uint64
 	Currency        string
 	MovieName       string
-	SeatNo          string
 	TheaterName     string
 	TheaterLocation string
 	ScreenName      string
 	TimeStart       int64
 	TimeEnd         int64
+	// Location is the time zone of the theater, every time of the PDF is rendered in it. Times are in UTC if
+	// it is nil.
+	Location *time.Location
+	// Tickets are the seats of the bookings of the PDF, all for the same showtime. A PDF of a single ticket
+	// has its QR code drawn by the QR code block, the QR codes of several tickets are drawn by the ticket QR
+	// codes block.
+	Tickets []PDFTicket
+	// Invoice is printed by the invoice info and tax summary blocks, they are skipped if it is nil.
+	Invoice *Invoice
+	// SeatMap is the seat map of the screen of the showtime, the seat map block is skipped if it is nil.
+	SeatMap *SeatMap
+	// Branding selects the layout of the PDF and customizes it.
+	Branding Branding
+	// Poster is a PNG or JPEG image of the movie, it is only drawn on the ticket card and skipped if it is
+	// empty.
+	Poster []byte
 }
 
-type PDFGenerator interface {
-	Generate(PDFGenerateParams) (*bytes.Buffer, error)
+type PDFGenerateResult struct {
+	Data *bytes.Buffer
+	// LayoutVersion identifies the layout the PDF was rendered with, as name@version.
+	LayoutVersion string
 }
 
-func NewPDFGenerator(
-	logger *zap.Logger,
-) PDFGenerator {
-	return &pdfGenerator{
-		logger: logger,
-	}
+// pdfGenerator renders all the tickets of the params in one PDF, with the layout named by the branding of the
+// params, or the default layout if it is unknown.
+type pdfGenerator struct {
+	renderSlots renderSlots
+	fonts       fontChain
+	// nameToLayout holds the embedded layouts and the layouts of the configured layout directory.
+	nameToLayout map[string]Layout
+	locale       string
+	logger       *zap.Logger
 }
 
-type pdfGenerator struct {
-	logger *zap.Logger
+func (g pdfGenerator) Format() Format {
+	return FormatPDF
 }
 
-func (g pdfGenerator) Generate(params PDFGenerateParams) (*bytes.Buffer, error) {
-	logger := g.logger.With(zap.Uint32("generate invoice", params.BookingId))
+func (g pdfGenerator) Generate(ctx context.Context, params PDFGenerateParams) (PDFGenerateResult, error) {
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, params.BookingId), g.logger)
+
+	if err := g.renderSlots.acquire(ctx); err != nil {
+		return PDFGenerateResult{}, err
+	}
+	defer g.renderSlots.release()
+
+	layout, ok := g.nameToLayout[params.Branding.Layout]
+	if !ok {
+		if params.Branding.Layout != "" {
+			logger.With(zap.String("layout", params.Branding.Layout)).Warn("unknown layout, using the default layout")
+		}
+		layout = g.nameToLayout[defaultLayoutName]
+	}
 
-	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf := newDocument(g.fonts)
 	pdf.AddPage()
 
-	// Generate QR code
-	qrInfo := fmt.Sprintf("%s - %s - %s - %s - Showtime: %s",
-		params.TheaterName,
-		params.ScreenName,
-		params.MovieName,
-		params.SeatNo,
-		g.unixTimeToVNDateTime(params.TimeStart),
-	)
-	qrCode, err := qrcode.New(qrInfo, qrcode.Medium)
-	if err != nil {
-		logger.With(zap.Error(err)).Error("failed to get create QR code")
-		return nil, err
+	rc := renderContext{
+		pdf:      pdf,
+		params:   params,
+		locale:   g.locale,
+		showtime: g.formatDateTime(time.UnixMilli(params.TimeStart), params.Location),
 	}
-
-	// Save QR code to file
-	qrFilename := "qr_code.png"
-	if err := qrCode.WriteFile(256, qrFilename); err != nil {
-		logger.With(zap.Error(err)).Error("failed to save QR code")
-		return nil, err
+	if params.Invoice != nil {
+		rc.invoiceIssuedAt = g.formatDateTime(params.Invoice.IssuedAt, params.Location)
 	}
-
-	// Adding QR code image to PDF
-	opts := gofpdf.ImageOptions{
-		ImageType: "PNG",
-		ReadDpi:   true,
+	if err := rc.draw(layout); err != nil {
+		logger.With(zap.String("layout", layout.LayoutVersion())).With(zap.Error(err)).Error("failed to draw PDF layout")
+		return PDFGenerateResult{}, err
 	}
-	pdf.ImageOptions(qrFilename, 165, 10, 30, 30, false, opts, 0, "")
-
-	// Set font
-	pdf.SetFont("Arial", "B", 12)
-
-	// Title
-	pdf.Cell(190, 10, "Movie Ticket Invoice")
-	pdf.Ln(12)
-
-	// Subtitle
-	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(190, 10, params.TheaterName, "", 1, "C", false, 0, "")
-	pdf.CellFormat(190, 10, params.TheaterLocation, "", 1, "C", false, 0, "")
-	pdf.Ln(5)
-
-	// Customer Info
-	pdf.SetFont("Arial", "B", 10)
-	pdf.Cell(30, 10, "Customer:")
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(160, 10, params.Username)
-	pdf.Ln(5)
-
-	pdf.SetFont("Arial", "B", 10)
-	pdf.Cell(30, 10, "Email:")
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(160, 10, params.Email)
-	pdf.Ln(10)
-
-	// Invoice table
-	pdf.SetFont("Arial", "B", 10)
-	pdf.CellFormat(190, 10, "Order Details", "1", 0, "C", false, 0, "")
-	pdf.Ln(10)
-
-	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(95, 10, "Item", "1", 0, "", false, 0, "")
-	pdf.CellFormat(35, 10, "Quantity", "1", 0, "C", false, 0, "")
-	pdf.CellFormat(30, 10, "Unit Price", "1", 0, "C", false, 0, "")
-	pdf.CellFormat(30, 10, "Total", "1", 0, "C", false, 0, "")
-	pdf.Ln(10)
-
-	itemDetail := fmt.Sprintf("%s - %s - %s - %s - %s",
-		params.MovieName,
-		params.TheaterName,
-		params.ScreenName,
-		g.unixTimeToVNDateTime(params.TimeStart),
-		params.SeatNo,
-	)
-
-	// Start a new line
-	pdf.SetX(pdf.GetX())
-	startY := pdf.GetY()
-
-	// Use MultiCell to ensure automatic line breaks
-	pdf.MultiCell(95, 10, itemDetail, "1", "", false)
-
-	// Get the height of the first cell
-	currentY := pdf.GetY()
-
-	// Move the X and Y positions back to the beginning of the line to print the following cells on the same row
-	pdf.SetXY(pdf.GetX()+95, startY)
-
-	// Create the following cells with the height of the MultiCell to ensure they are the same height as the MultiCell
-	pdf.CellFormat(35, currentY-startY, fmt.Sprintf("%d", 1), "1", 0, "C", false, 0, "")
-	pdf.CellFormat(30, currentY-startY, fmt.Sprintf("%d %s", params.Amount, params.Currency), "1", 0, "C", false, 0, "")
-	pdf.CellFormat(30, currentY-startY, fmt.Sprintf("%d %s", params.Amount, params.Currency), "1", 0, "C", false, 0, "")
-	pdf.Ln(-1)
-
-	// Footer note
-	pdf.SetFont("Arial", "I", 10)
-	pdf.CellFormat(190, 10, "Thank you!", "", 0, "C", false, 0, "")
 
 	// Output the PDF
 	var buf bytes.Buffer
 	if err := pdf.Output(&buf); err != nil {
 		logger.With(zap.Error(err)).Error("failed to write PDF to buffer")
-		return nil, err
+		return PDFGenerateResult{}, err
 	}
 
-	return &buf, nil
+	return PDFGenerateResult{
+		Data:          &buf,
+		LayoutVersion: layout.LayoutVersion(),
+	}, nil
 }
 
-// unixTimeToVNDateTime converts a Unix timestamp (in milliseconds) to a formatted Vietnamese date-time string.
-func (g pdfGenerator) unixTimeToVNDateTime(timestamp int64) string {
-	logger := g.logger.With(zap.Int64("convert unix timestamp to VN Date time", timestamp))
-
-	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
-	if err != nil {
-		logger.With(zap.Error(err)).Error("failed to load location")
-		return ""
+// formatDateTime formats t as a date-time of location, in the locale of the generator.
+func (g pdfGenerator) formatDateTime(t time.Time, location *time.Location) string {
+	if location == nil {
+		location = time.UTC
 	}
-	// Convert milliseconds to seconds for time.Unix
-	t := time.Unix(timestamp/1000, (timestamp%1000)*1000000).In(loc)
-	return t.Format("Monday, 02/01/2006 15:04:05")
+
+	return formatDateTime(t.In(location), g.locale)
 }
@@ -0,0 +1,103 @@
+package pdfgenerator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	taxSummaryLabelWidth  = 130
+	taxSummaryAmountWidth = 60
+)
+
+// Invoice is the legal invoice the PDF is the copy of.
+type Invoice struct {
+	InvoiceNo     string
+	IssuedAt      time.Time
+	SellerName    string
+	SellerTaxId   string
+	SellerAddress string
+	TaxName       string
+	// TaxRateBasisPoints is the tax rate, 1000 basis points being 10%.
+	TaxRateBasisPoints uint32
+	NetAmount          uint64
+	TaxAmount          uint64
+	GrossAmount        uint64
+	// Lines are the amounts of the tickets of the invoice, the order table prices a ticket without a line at
+	// its booking amount.
+	Lines []InvoiceLine
+}
+
+type InvoiceLine struct {
+	BookingId   uint32
+	NetAmount   uint64
+	TaxAmount   uint64
+	GrossAmount uint64
+}
+
+// formatTaxRate formats a rate in basis points as a percentage, without trailing zeros.
+func formatTaxRate(basisPoints uint32) string {
+	rate := strconv.FormatFloat(float64(basisPoints)/100, 'f', 2, 64)
+	rate = strings.TrimSuffix(strings.TrimRight(rate, "0"), ".")
+	return rate + "%"
+}
+
+func drawInvoiceInfo(rc renderContext, block LayoutBlock) error {
+	invoice := rc.params.Invoice
+	if invoice == nil {
+		return nil
+	}
+
+	labelWidth := block.LabelWidth
+	if labelWidth <= 0 {
+		labelWidth = defaultInfoLabelWidth
+	}
+
+	rows := [][2]string{
+		{"Invoice No:", invoice.InvoiceNo},
+		{"Issue Date:", rc.invoiceIssuedAt},
+		{"Seller:", invoice.SellerName},
+		{"Tax ID:", invoice.SellerTaxId},
+		{"Address:", invoice.SellerAddress},
+	}
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+
+		rc.pdf.setFont(fontStyleBold, block.FontSize)
+		rc.pdf.cell(labelWidth, lineHeight, row[0])
+		rc.pdf.setFont(fontStyleRegular, block.FontSize)
+		rc.pdf.cell(pageWidth-labelWidth, lineHeight, row[1])
+		rc.pdf.Ln(5)
+	}
+
+	return nil
+}
+
+func drawTaxSummary(rc renderContext, block LayoutBlock) error {
+	invoice := rc.params.Invoice
+	if invoice == nil {
+		return nil
+	}
+
+	rows := [][2]string{
+		{"Net Amount", rc.formatAmount(invoice.NetAmount)},
+		{fmt.Sprintf("%s (%s)", invoice.TaxName, formatTaxRate(invoice.TaxRateBasisPoints)), rc.formatAmount(invoice.TaxAmount)},
+		{"Total", rc.formatAmount(invoice.GrossAmount)},
+	}
+	for i, row := range rows {
+		style := fontStyleRegular
+		if i == len(rows)-1 {
+			style = fontStyleBold
+		}
+
+		rc.pdf.setFont(style, block.FontSize)
+		rc.pdf.cellFormat(taxSummaryLabelWidth, lineHeight, row[0], "1", 0, "R")
+		rc.pdf.cellFormat(taxSummaryAmountWidth, lineHeight, row[1], "1", 1, "R")
+	}
+
+	return nil
+}
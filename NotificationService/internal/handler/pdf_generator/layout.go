@@ -0,0 +1,459 @@
+package pdfgenerator
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultLayoutName = "default"
+
+	BlockTypeLogo          = "logo"
+	BlockTypeQRCode        = "qr_code"
+	BlockTypeTicketQRCodes = "ticket_qr_codes"
+	BlockTypeHeader        = "header"
+	BlockTypeTheaterInfo   = "theater_info"
+	BlockTypeCustomerInfo  = "customer_info"
+	BlockTypeOrderTable    = "order_table"
+	BlockTypeInvoiceInfo   = "invoice_info"
+	BlockTypeTaxSummary    = "tax_summary"
+	BlockTypeSeatMap       = "seat_map"
+	BlockTypeText          = "text"
+	BlockTypeLegalFooter   = "legal_footer"
+
+	pageWidth               = 190
+	lineHeight              = 10
+	defaultFontSize         = 10
+	defaultQRCodeSize       = 30
+	ticketQRCodeSpacing     = 8
+	defaultLogoHeight       = 15
+	defaultInfoLabelWidth   = 30
+	orderTableItemWidth     = 95
+	orderTableQuantityWidth = 35
+	orderTablePriceWidth    = 30
+)
+
+var (
+	//go:embed layouts/*.yaml
+	embeddedLayoutDirectory embed.FS
+)
+
+// LayoutBlock is a part of an invoice. Blocks are drawn one below the other in the order of the layout,
+// except the logo and the QR code which are drawn at X and Y when they are set. The QR code block is only
+// drawn for a single ticket and the ticket QR codes block only for several.
+type LayoutBlock struct {
+	Type string  `yaml:"type"`
+	X    float64 `yaml:"x"`
+	Y    float64 `yaml:"y"`
+	// Size is the side of the QR code, and of the QR codes of the tickets.
+	Size float64 `yaml:"size"`
+	// Height is the height of the logo, its width follows its aspect ratio, and the maximum height of the
+	// seats of the seat map.
+	Height float64 `yaml:"height"`
+	// Text is the text of the header and text blocks, and the caption of the seat map and ticket QR codes.
+	Text      string  `yaml:"text"`
+	FontStyle string  `yaml:"font_style"`
+	FontSize  float64 `yaml:"font_size"`
+	// Align is L, C or R.
+	Align string `yaml:"align"`
+	// LabelWidth is the width of the labels of the customer and invoice info.
+	LabelWidth   float64 `yaml:"label_width"`
+	SpacingAfter float64 `yaml:"spacing_after"`
+}
+
+type Layout struct {
+	Name    string        `yaml:"name"`
+	Version int           `yaml:"version"`
+	Blocks  []LayoutBlock `yaml:"blocks"`
+}
+
+// LayoutVersion identifies the layout in the records of the PDFs rendered with it.
+func (l Layout) LayoutVersion() string {
+	return fmt.Sprintf("%s@%d", l.Name, l.Version)
+}
+
+func (l Layout) validate() error {
+	if l.Name == "" {
+		return fmt.Errorf("layout has no name")
+	}
+	if l.Version <= 0 {
+		return fmt.Errorf("layout %s has no version", l.Name)
+	}
+
+	for i, block := range l.Blocks {
+		if _, ok := blockTypeToDrawFunc[block.Type]; !ok {
+			return fmt.Errorf("block %d of layout %s has unknown type %q", i, l.Name, block.Type)
+		}
+		if block.FontStyle != fontStyleRegular && block.FontStyle != fontStyleBold && block.FontStyle != fontStyleItalic {
+			return fmt.Errorf("block %d of layout %s has unknown font style %q", i, l.Name, block.FontStyle)
+		}
+	}
+
+	return nil
+}
+
+// loadLayouts returns the embedded layouts, together with the layouts of layoutDirectory if it is set. A
+// layout of layoutDirectory replaces the embedded layout with the same name.
+func loadLayouts(layoutDirectory string) (map[string]Layout, error) {
+	nameToLayout := make(map[string]Layout)
+	if err := loadLayoutDirectory(embeddedLayoutDirectory, "layouts", nameToLayout); err != nil {
+		return nil, err
+	}
+	if layoutDirectory != "" {
+		if err := loadLayoutDirectory(os.DirFS(layoutDirectory), ".", nameToLayout); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := nameToLayout[defaultLayoutName]; !ok {
+		return nil, fmt.Errorf("no %s layout", defaultLayoutName)
+	}
+
+	return nameToLayout, nil
+}
+
+func loadLayoutDirectory(fileSystem fs.FS, directory string, nameToLayout map[string]Layout) error {
+	entries, err := fs.ReadDir(fileSystem, directory)
+	if err != nil {
+		return fmt.Errorf("failed to read layout directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		extension := path.Ext(entry.Name())
+		if entry.IsDir() || (extension != ".yaml" && extension != ".yml" && extension != ".json") {
+			continue
+		}
+
+		// JSON is a subset of YAML, so both are parsed the same way.
+		data, err := fs.ReadFile(fileSystem, path.Join(directory, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read layout %s: %w", entry.Name(), err)
+		}
+
+		var layout Layout
+		if err := yaml.UnmarshalStrict(data, &layout); err != nil {
+			return fmt.Errorf("failed to parse layout %s: %w", entry.Name(), err)
+		}
+		if err := layout.validate(); err != nil {
+			return err
+		}
+
+		nameToLayout[layout.Name] = layout
+	}
+
+	return nil
+}
+
+// renderContext is what the blocks of a layout are drawn from.
+type renderContext struct {
+	pdf    *document
+	params PDFGenerateParams
+	locale string
+	// showtime is the formatted start of the showtime.
+	showtime string
+	// invoiceIssuedAt is the formatted issue time of the invoice.
+	invoiceIssuedAt string
+}
+
+func (rc renderContext) formatAmount(amount uint64) string {
+	return formatAmount(amount, rc.params.Currency, rc.locale)
+}
+
+type drawFunc func(rc renderContext, block LayoutBlock) error
+
+var blockTypeToDrawFunc = map[string]drawFunc{
+	BlockTypeLogo:          drawLogo,
+	BlockTypeQRCode:        drawQRCode,
+	BlockTypeTicketQRCodes: drawTicketQRCodes,
+	BlockTypeHeader:        drawHeader,
+	BlockTypeTheaterInfo:   drawTheaterInfo,
+	BlockTypeCustomerInfo:  drawCustomerInfo,
+	BlockTypeOrderTable:    drawOrderTable,
+	BlockTypeInvoiceInfo:   drawInvoiceInfo,
+	BlockTypeTaxSummary:    drawTaxSummary,
+	BlockTypeSeatMap:       drawSeatMap,
+	BlockTypeText:          drawText,
+	BlockTypeLegalFooter:   drawLegalFooter,
+}
+
+func (rc renderContext) draw(layout Layout) error {
+	for _, block := range layout.Blocks {
+		if block.FontSize <= 0 {
+			block.FontSize = defaultFontSize
+		}
+
+		if err := blockTypeToDrawFunc[block.Type](rc, block); err != nil {
+			return fmt.Errorf("failed to draw %s block: %w", block.Type, err)
+		}
+		if block.SpacingAfter > 0 {
+			rc.pdf.Ln(block.SpacingAfter)
+		}
+	}
+
+	return rc.pdf.Error()
+}
+
+func drawLogo(rc renderContext, block LayoutBlock) error {
+	if len(rc.params.Branding.Logo) == 0 {
+		return nil
+	}
+
+	var imageType string
+	switch http.DetectContentType(rc.params.Branding.Logo) {
+	case "image/png":
+		imageType = "PNG"
+	case "image/jpeg":
+		imageType = "JPG"
+	default:
+		return fmt.Errorf("logo is neither a PNG nor a JPEG image")
+	}
+
+	height := block.Height
+	if height <= 0 {
+		height = defaultLogoHeight
+	}
+
+	// The image name is only used within this document.
+	logoImageName := "logo"
+	opts := gofpdf.ImageOptions{
+		ImageType: imageType,
+		ReadDpi:   true,
+	}
+	rc.pdf.RegisterImageOptionsReader(logoImageName, opts, bytes.NewReader(rc.params.Branding.Logo))
+
+	// A logo without position flows with the blocks, moving the next ones below it.
+	flow := block.X == 0 && block.Y == 0
+	x, y := block.X, block.Y
+	if flow {
+		x, y = rc.pdf.GetX(), rc.pdf.GetY()
+	}
+	rc.pdf.ImageOptions(logoImageName, x, y, 0, height, flow, opts, 0, "")
+
+	return nil
+}
+
+func drawQRCode(rc renderContext, block LayoutBlock) error {
+	if len(rc.params.Tickets) != 1 {
+		return nil
+	}
+
+	size := block.Size
+	if size <= 0 {
+		size = defaultQRCodeSize
+	}
+
+	return drawTicketQRCode(rc, rc.params.Tickets[0], "qr_code", block.X, block.Y, size)
+}
+
+// drawTicketQRCode draws the QR code of the ticket token of ticket at x and y. The image name is only used
+// within the document and must be unique in it.
+func drawTicketQRCode(rc renderContext, ticket PDFTicket, imageName string, x, y, size float64) error {
+	qrCode, err := qrcode.New(ticket.TicketToken, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	// Encode QR code in memory, so that concurrent renders do not share a file
+	qrPNG, err := qrCode.PNG(256)
+	if err != nil {
+		return err
+	}
+
+	opts := gofpdf.ImageOptions{
+		ImageType: "PNG",
+		ReadDpi:   true,
+	}
+	rc.pdf.RegisterImageOptionsReader(imageName, opts, bytes.NewReader(qrPNG))
+	rc.pdf.ImageOptions(imageName, x, y, size, size, false, opts, 0, "")
+
+	return nil
+}
+
+// drawTicketQRCodes draws the QR codes of the tickets in a grid, each above its seat number, so that every
+// member of a group can be let in with their own code.
+func drawTicketQRCodes(rc renderContext, block LayoutBlock) error {
+	if len(rc.params.Tickets) < 2 {
+		return nil
+	}
+
+	pdf := rc.pdf
+	size := block.Size
+	if size <= 0 {
+		size = defaultQRCodeSize
+	}
+
+	cellWidth := size + ticketQRCodeSpacing
+	columnCount := max(1, int((pageWidth+ticketQRCodeSpacing)/cellWidth))
+	cellHeight := size + lineHeight/2 + ticketQRCodeSpacing
+	leftMargin, _, _, _ := pdf.GetMargins()
+
+	if block.Text != "" {
+		pdf.ensureSpace(lineHeight + cellHeight)
+		pdf.setFont(fontStyleBold, block.FontSize)
+		pdf.cellFormat(pageWidth, lineHeight, block.Text, "", 1, "C")
+	}
+
+	for i, ticket := range rc.params.Tickets {
+		column := i % columnCount
+		if column == 0 {
+			pdf.ensureSpace(cellHeight)
+		}
+
+		x := leftMargin + float64(column)*cellWidth
+		y := pdf.GetY()
+		if err := drawTicketQRCode(rc, ticket, fmt.Sprintf("ticket_qr_code_%d", i), x, y, size); err != nil {
+			return err
+		}
+
+		pdf.SetXY(x, y+size)
+		pdf.setFont(fontStyleRegular, block.FontSize)
+		pdf.cellFormat(size, lineHeight/2, ticket.SeatNo, "", 0, "C")
+
+		if column == columnCount-1 || i == len(rc.params.Tickets)-1 {
+			pdf.SetXY(leftMargin, y+cellHeight)
+		} else {
+			pdf.SetY(y)
+		}
+	}
+
+	return nil
+}
+
+func drawHeader(rc renderContext, block LayoutBlock) error {
+	if rc.params.Branding.PrimaryColor != nil {
+		rc.pdf.SetTextColor(rc.params.Branding.PrimaryColor.R, rc.params.Branding.PrimaryColor.G, rc.params.Branding.PrimaryColor.B)
+		defer rc.pdf.SetTextColor(0, 0, 0)
+	}
+
+	rc.pdf.setFont(block.FontStyle, block.FontSize)
+	rc.pdf.cellFormat(pageWidth, lineHeight, block.Text, "", 0, block.Align)
+
+	return nil
+}
+
+func drawTheaterInfo(rc renderContext, block LayoutBlock) error {
+	align := block.Align
+	if align == "" {
+		align = "C"
+	}
+
+	rc.pdf.setFont(block.FontStyle, block.FontSize)
+	rc.pdf.cellFormat(pageWidth, lineHeight, rc.params.TheaterName, "", 1, align)
+	rc.pdf.cellFormat(pageWidth, lineHeight, rc.params.TheaterLocation, "", 1, align)
+
+	return nil
+}
+
+func drawCustomerInfo(rc renderContext, block LayoutBlock) error {
+	labelWidth := block.LabelWidth
+	if labelWidth <= 0 {
+		labelWidth = defaultInfoLabelWidth
+	}
+
+	rc.pdf.setFont(fontStyleBold, block.FontSize)
+	rc.pdf.cell(labelWidth, lineHeight, "Customer:")
+	rc.pdf.setFont(fontStyleRegular, block.FontSize)
+	rc.pdf.cell(pageWidth-labelWidth, lineHeight, rc.params.Username)
+	rc.pdf.Ln(5)
+
+	rc.pdf.setFont(fontStyleBold, block.FontSize)
+	rc.pdf.cell(labelWidth, lineHeight, "Email:")
+	rc.pdf.setFont(fontStyleRegular, block.FontSize)
+	rc.pdf.cell(pageWidth-labelWidth, lineHeight, rc.params.Email)
+	rc.pdf.Ln(lineHeight)
+
+	return nil
+}
+
+func drawOrderTable(rc renderContext, block LayoutBlock) error {
+	pdf := rc.pdf
+
+	// Invoice table
+	fill := rc.params.Branding.PrimaryColor != nil
+	if fill {
+		pdf.SetFillColor(rc.params.Branding.PrimaryColor.R, rc.params.Branding.PrimaryColor.G, rc.params.Branding.PrimaryColor.B)
+		pdf.SetTextColor(255, 255, 255)
+	}
+	pdf.setFont(fontStyleBold, block.FontSize)
+	pdf.CellFormat(pageWidth, lineHeight, pdf.useFontFor("Order Details"), "1", 0, "C", fill, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(lineHeight)
+
+	pdf.setFont(fontStyleRegular, block.FontSize)
+	pdf.cellFormat(orderTableItemWidth, lineHeight, "Item", "1", 0, "")
+	pdf.cellFormat(orderTableQuantityWidth, lineHeight, "Quantity", "1", 0, "C")
+	pdf.cellFormat(orderTablePriceWidth, lineHeight, "Unit Price", "1", 0, "C")
+	pdf.cellFormat(orderTablePriceWidth, lineHeight, "Total", "1", 0, "C")
+	pdf.Ln(lineHeight)
+
+	// Invoices list the price before tax, the tax is added by the tax summary.
+	bookingIdToNetAmount := make(map[uint32]uint64)
+	if rc.params.Invoice != nil {
+		for _, line := range rc.params.Invoice.Lines {
+			bookingIdToNetAmount[line.BookingId] = line.NetAmount
+		}
+	}
+
+	for _, ticket := range rc.params.Tickets {
+		itemDetail := fmt.Sprintf("%s - %s - %s - %s - %s",
+			rc.params.MovieName,
+			rc.params.TheaterName,
+			rc.params.ScreenName,
+			rc.showtime,
+			ticket.SeatNo,
+		)
+
+		// Start a new line
+		pdf.SetX(pdf.GetX())
+		startY := pdf.GetY()
+
+		// Use MultiCell to ensure automatic line breaks
+		pdf.multiCell(orderTableItemWidth, lineHeight, itemDetail, "1", "")
+
+		// Get the height of the first cell
+		currentY := pdf.GetY()
+
+		// Move the X and Y positions back to the beginning of the line to print the following cells on the same row
+		pdf.SetXY(pdf.GetX()+orderTableItemWidth, startY)
+
+		// Create the following cells with the height of the MultiCell to ensure they are the same height as the MultiCell
+		unitPrice, ok := bookingIdToNetAmount[ticket.BookingId]
+		if !ok {
+			unitPrice = ticket.Amount
+		}
+		price := rc.formatAmount(unitPrice)
+		pdf.cellFormat(orderTableQuantityWidth, currentY-startY, fmt.Sprintf("%d", 1), "1", 0, "C")
+		pdf.cellFormat(orderTablePriceWidth, currentY-startY, price, "1", 0, "C")
+		pdf.cellFormat(orderTablePriceWidth, currentY-startY, price, "1", 0, "C")
+		pdf.Ln(-1)
+	}
+
+	return nil
+}
+
+func drawText(rc renderContext, block LayoutBlock) error {
+	rc.pdf.setFont(block.FontStyle, block.FontSize)
+	rc.pdf.cellFormat(pageWidth, lineHeight, block.Text, "", 0, block.Align)
+
+	return nil
+}
+
+func drawLegalFooter(rc renderContext, block LayoutBlock) error {
+	if rc.params.Branding.LegalFooter == "" {
+		return nil
+	}
+
+	rc.pdf.setFont(block.FontStyle, block.FontSize)
+	rc.pdf.multiCell(pageWidth, block.FontSize/2, rc.params.Branding.LegalFooter, "", block.Align)
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package pdfgenerator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadLayouts(t *testing.T) {
+	const customLayout = `
+name: custom
+version: 1
+blocks:
+  - type: header
+    text: Custom Invoice
+    font_style: B
+  - type: order_table
+`
+
+	testCases := []struct {
+		name              string
+		files             map[string]string
+		noDirectory       bool
+		wantErr           bool
+		wantLayoutNames   []string
+		wantLayoutVersion map[string]string
+	}{
+		{
+			name:            "embedded layouts only",
+			noDirectory:     true,
+			wantLayoutNames: []string{"default"},
+		},
+		{
+			name: "additional layouts in YAML and JSON",
+			files: map[string]string{
+				"custom.yaml": customLayout,
+				"other.json":  `{"name": "other", "version": 2, "blocks": [{"type": "text", "text": "Thank you"}]}`,
+			},
+			wantLayoutNames:   []string{"custom", "default", "other"},
+			wantLayoutVersion: map[string]string{"custom": "custom@1", "other": "other@2"},
+		},
+		{
+			name: "layout replacing the embedded default layout",
+			files: map[string]string{
+				"default.yml": "name: default\nversion: 99\nblocks:\n  - type: order_table\n",
+			},
+			wantLayoutNames:   []string{"default"},
+			wantLayoutVersion: map[string]string{"default": "default@99"},
+		},
+		{
+			name: "files other than layouts are ignored",
+			files: map[string]string{
+				"README.md":   "# Layouts",
+				"custom.yaml": customLayout,
+			},
+			wantLayoutNames: []string{"custom", "default"},
+		},
+		{
+			name: "unknown block type",
+			files: map[string]string{
+				"custom.yaml": "name: custom\nversion: 1\nblocks:\n  - type: watermark\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown font style",
+			files: map[string]string{
+				"custom.yaml": "name: custom\nversion: 1\nblocks:\n  - type: text\n    font_style: U\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			files: map[string]string{
+				"custom.yaml": "name: custom\nversion: 1\ncolor: red\nblocks: []\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "layout without name",
+			files: map[string]string{
+				"custom.yaml": "version: 1\nblocks: []\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "layout without version",
+			files: map[string]string{
+				"custom.yaml": "name: custom\nblocks: []\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed layout",
+			files: map[string]string{
+				"custom.yaml": "name: [custom\n",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			layoutDirectory := ""
+			if !testCase.noDirectory {
+				layoutDirectory = t.TempDir()
+				for filename, content := range testCase.files {
+					if err := os.WriteFile(filepath.Join(layoutDirectory, filename), []byte(content), 0o600); err != nil {
+						t.Fatalf("failed to write layout %s: %v", filename, err)
+					}
+				}
+			}
+
+			nameToLayout, err := loadLayouts(layoutDirectory)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("loadLayouts() error = %v, want error %t", err, testCase.wantErr)
+			}
+			if testCase.wantErr {
+				return
+			}
+
+			layoutNames := make([]string, 0, len(nameToLayout))
+			for name := range nameToLayout {
+				layoutNames = append(layoutNames, name)
+			}
+			slices.Sort(layoutNames)
+			if !slices.Equal(layoutNames, testCase.wantLayoutNames) {
+				t.Errorf("loadLayouts() layouts = %v, want %v", layoutNames, testCase.wantLayoutNames)
+			}
+
+			for name, wantLayoutVersion := range testCase.wantLayoutVersion {
+				if got := nameToLayout[name].LayoutVersion(); got != wantLayoutVersion {
+					t.Errorf("layout %s has version %q, want %q", name, got, wantLayoutVersion)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadLayoutsMissingDirectory(t *testing.T) {
+	if _, err := loadLayouts(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("loadLayouts() of a missing directory succeeded, want error")
+	}
+}
@@ -0,0 +1,40 @@
+package pdfgenerator
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	LocaleVietnamese = "vi"
+	LocaleEnglish    = "en"
+
+	defaultLocale = LocaleVietnamese
+)
+
+type dateTimeLocale struct {
+	weekdays [7]string
+	layout   string
+}
+
+var localeToDateTimeLocale = map[string]dateTimeLocale{
+	LocaleVietnamese: {
+		weekdays: [7]string{"Chủ Nhật", "Thứ Hai", "Thứ Ba", "Thứ Tư", "Thứ Năm", "Thứ Sáu", "Thứ Bảy"},
+		layout:   "02/01/2006 15:04",
+	},
+	LocaleEnglish: {
+		weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		layout:   "Jan 2, 2006 3:04 PM",
+	},
+}
+
+// formatDateTime formats t as a weekday, date and time in locale, falling back to the default locale if it
+// is not supported.
+func formatDateTime(t time.Time, locale string) string {
+	dateTimeLocale, ok := localeToDateTimeLocale[locale]
+	if !ok {
+		dateTimeLocale = localeToDateTimeLocale[defaultLocale]
+	}
+
+	return fmt.Sprintf("%s, %s", dateTimeLocale.weekdays[t.Weekday()], t.Format(dateTimeLocale.layout))
+}
@@ -0,0 +1,111 @@
+package pdfgenerator
+
+import (
+	"NotificationService/internal/configs"
+	"context"
+	"fmt"
+	"runtime"
+
+	"go.uber.org/zap"
+)
+
+// Format is the file format a renderer renders bookings in.
+type Format string
+
+const (
+	FormatPDF Format = "pdf"
+	FormatPNG Format = "png"
+)
+
+// Extension is the file extension of the format, with its leading dot.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+type Renderer interface {
+	// Format is the format of the files rendered by the renderer.
+	Format() Format
+	// Generate is safe for concurrent use. Renderers share the configured number of rendering slots,
+	// Generate waits for a free slot and gives up if ctx is done first.
+	Generate(ctx context.Context, params PDFGenerateParams) (PDFGenerateResult, error)
+}
+
+// Renderers are the renderers of every supported format, at most one per format.
+type Renderers []Renderer
+
+// NewRenderers registers the PDF renderer, which renders all the tickets of the params in one document,
+// and the PNG renderer, which renders the ticket card of a single ticket. They share the fonts and the
+// rendering slots.
+func NewRenderers(
+	pdfGeneratorConfig configs.PDFGenerator,
+	logger *zap.Logger,
+) (Renderers, error) {
+	concurrency := pdfGeneratorConfig.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	fonts, err := newFontChain(pdfGeneratorConfig.Fonts)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := pdfGeneratorConfig.Locale
+	if locale == "" {
+		locale = defaultLocale
+	}
+	if _, ok := localeToDateTimeLocale[locale]; !ok {
+		return nil, fmt.Errorf("unsupported pdf generator locale: %s", locale)
+	}
+
+	nameToLayout, err := loadLayouts(pdfGeneratorConfig.LayoutDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make(renderSlots, concurrency)
+	return Renderers{
+		&pdfGenerator{
+			renderSlots:  slots,
+			fonts:        fonts,
+			nameToLayout: nameToLayout,
+			locale:       locale,
+			logger:       logger,
+		},
+		&ticketCardGenerator{
+			renderSlots: slots,
+			fonts:       fonts,
+			locale:      locale,
+			logger:      logger,
+		},
+	}, nil
+}
+
+// Get returns the renderer of format, or false if no renderer renders it.
+func (r Renderers) Get(format Format) (Renderer, bool) {
+	for _, renderer := range r {
+		if renderer.Format() == format {
+			return renderer, true
+		}
+	}
+
+	return nil, false
+}
+
+// renderSlots bounds the number of files rendered at the same time, rendering is CPU bound and every render
+// holds the whole file in memory.
+type renderSlots chan struct{}
+
+// acquire waits for a free slot, or returns the error of ctx if it is done first.
+func (s renderSlots) acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s renderSlots) release() {
+	<-s
+}
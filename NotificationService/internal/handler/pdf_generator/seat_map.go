@@ -0,0 +1,232 @@
+package pdfgenerator
+
+import (
+	"sort"
+)
+
+const (
+	defaultSeatMapHeight  = 45
+	maxSeatMapSeatSize    = 6
+	seatMapRowLabelWidth  = 6
+	seatMapScreenHeight   = 2
+	seatMapLegendSize     = 3
+	seatMapLegendFontSize = 8
+	// defaultLineWidth is the line width gofpdf starts documents with.
+	defaultLineWidth = 0.2
+)
+
+var (
+	// seatTypeShades are the gray levels of the seat types, in the order the seat types first appear in the
+	// seat map.
+	seatTypeShades = []int{255, 215, 175, 135}
+	// bookedSeatColor highlights the booked seats when the branding has no primary color.
+	bookedSeatColor = Color{R: 220, G: 38, B: 38}
+)
+
+type SeatMapSeat struct {
+	Row string
+	// Column starts at 1 on the left of the screen.
+	Column   uint32
+	SeatType string
+	Booked   bool
+}
+
+// SeatMap is the layout of the seats of a screen, drawn as a grid with the screen at the top.
+type SeatMap struct {
+	// ColumnCount is the number of seats of a row of the screen, it is raised to the largest column of the
+	// seats if it is smaller.
+	ColumnCount uint32
+	Seats       []SeatMapSeat
+}
+
+// rows returns the rows of the seat map from the screen to the back, "B" coming before "AA".
+func (s SeatMap) rows() []string {
+	rowSet := make(map[string]bool)
+	for _, seat := range s.Seats {
+		rowSet[seat.Row] = true
+	}
+
+	rows := make([]string, 0, len(rowSet))
+	for row := range rowSet {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if len(rows[i]) != len(rows[j]) {
+			return len(rows[i]) < len(rows[j])
+		}
+		return rows[i] < rows[j]
+	})
+
+	return rows
+}
+
+func (s SeatMap) columnCount() uint32 {
+	columnCount := s.ColumnCount
+	for _, seat := range s.Seats {
+		columnCount = max(columnCount, seat.Column)
+	}
+
+	return columnCount
+}
+
+// seatTypes returns the seat types in the order they first appear, front row first.
+func (s SeatMap) seatTypes(rows []string) []string {
+	rowToIndex := make(map[string]int, len(rows))
+	for i, row := range rows {
+		rowToIndex[row] = i
+	}
+
+	seats := make([]SeatMapSeat, len(s.Seats))
+	copy(seats, s.Seats)
+	sort.SliceStable(seats, func(i, j int) bool {
+		if seats[i].Row != seats[j].Row {
+			return rowToIndex[seats[i].Row] < rowToIndex[seats[j].Row]
+		}
+		return seats[i].Column < seats[j].Column
+	})
+
+	var (
+		seatTypes   []string
+		seatTypeSet = make(map[string]bool)
+	)
+	for _, seat := range seats {
+		if !seatTypeSet[seat.SeatType] {
+			seatTypeSet[seat.SeatType] = true
+			seatTypes = append(seatTypes, seat.SeatType)
+		}
+	}
+
+	return seatTypes
+}
+
+func drawSeatMap(rc renderContext, block LayoutBlock) error {
+	seatMap := rc.params.SeatMap
+	if seatMap == nil || len(seatMap.Seats) == 0 {
+		return nil
+	}
+
+	pdf := rc.pdf
+	rows := seatMap.rows()
+	columnCount := seatMap.columnCount()
+	seatTypes := seatMap.seatTypes(rows)
+
+	seatTypeToShade := make(map[string]int, len(seatTypes))
+	for i, seatType := range seatTypes {
+		seatTypeToShade[seatType] = seatTypeShades[min(i, len(seatTypeShades)-1)]
+	}
+
+	highlightColor := bookedSeatColor
+	if rc.params.Branding.PrimaryColor != nil {
+		highlightColor = *rc.params.Branding.PrimaryColor
+	}
+
+	height := block.Height
+	if height <= 0 {
+		height = defaultSeatMapHeight
+	}
+
+	// Seats are squares spaced by a fifth of their pitch, the pitch is sized so that the grid fits the page
+	// width and the height of the block.
+	seatPitch := min(
+		float64(maxSeatMapSeatSize),
+		(pageWidth-2*seatMapRowLabelWidth)/float64(columnCount),
+		height/float64(len(rows)),
+	)
+	seatSize := seatPitch * 0.8
+	gridWidth := seatPitch * float64(columnCount)
+	leftMargin, _, _, _ := pdf.GetMargins()
+	gridX := leftMargin + (pageWidth-gridWidth)/2
+
+	// The seat map is kept on one page, below its caption.
+	mapHeight := seatMapScreenHeight + 4 + seatPitch/2 + float64(len(rows))*seatPitch + 2 + 4
+	if block.Text != "" {
+		mapHeight += lineHeight
+	}
+	pdf.ensureSpace(mapHeight)
+
+	if block.Text != "" {
+		pdf.setFont(fontStyleBold, block.FontSize)
+		pdf.cellFormat(pageWidth, lineHeight, block.Text, "", 1, "C")
+	}
+
+	// Screen, at the front of the room
+	y := pdf.GetY()
+	pdf.SetFillColor(120, 120, 120)
+	pdf.Rect(gridX, y, gridWidth, seatMapScreenHeight, "F")
+	y += seatMapScreenHeight
+	pdf.SetXY(gridX, y)
+	pdf.setFont(fontStyleRegular, seatMapLegendFontSize)
+	pdf.cellFormat(gridWidth, 4, "SCREEN", "", 0, "C")
+	y += 4 + seatPitch/2
+
+	// Row labels are sized to the seats, so that dense rooms stay readable.
+	rowLabelFontSize := min(block.FontSize, seatPitch*2.5)
+
+	rowToIndex := make(map[string]int, len(rows))
+	for i, row := range rows {
+		rowToIndex[row] = i
+
+		pdf.SetXY(gridX-seatMapRowLabelWidth, y+float64(i)*seatPitch)
+		pdf.setFont(fontStyleRegular, rowLabelFontSize)
+		pdf.cellFormat(seatMapRowLabelWidth, seatSize, row, "", 0, "C")
+	}
+
+	pdf.SetDrawColor(80, 80, 80)
+	pdf.SetLineWidth(0.1)
+	for _, seat := range seatMap.Seats {
+		if seat.Column == 0 {
+			continue
+		}
+
+		if seat.Booked {
+			pdf.SetFillColor(highlightColor.R, highlightColor.G, highlightColor.B)
+		} else {
+			shade := seatTypeToShade[seat.SeatType]
+			pdf.SetFillColor(shade, shade, shade)
+		}
+
+		seatX := gridX + float64(seat.Column-1)*seatPitch + (seatPitch-seatSize)/2
+		seatY := y + float64(rowToIndex[seat.Row])*seatPitch
+		pdf.Rect(seatX, seatY, seatSize, seatSize, "FD")
+	}
+	y += float64(len(rows)) * seatPitch
+
+	// Legend
+	legendX := gridX
+	legendY := y + 2
+	pdf.setFont(fontStyleRegular, seatMapLegendFontSize)
+	drawLegendEntry := func(text string, fill Color) {
+		pdf.SetFillColor(fill.R, fill.G, fill.B)
+		pdf.Rect(legendX, legendY+0.5, seatMapLegendSize, seatMapLegendSize, "FD")
+		pdf.SetXY(legendX+seatMapLegendSize+1, legendY)
+		text = pdf.useFontFor(text)
+		textWidth := pdf.GetStringWidth(text) + 1
+		pdf.CellFormat(textWidth, 4, text, "", 0, "L", false, 0, "")
+		legendX += seatMapLegendSize + 1 + textWidth + 4
+	}
+	bookedSeatCount := 0
+	for _, seat := range seatMap.Seats {
+		if seat.Booked {
+			bookedSeatCount++
+		}
+	}
+	if bookedSeatCount > 1 {
+		drawLegendEntry("Your seats", highlightColor)
+	} else {
+		drawLegendEntry("Your seat", highlightColor)
+	}
+	for _, seatType := range seatTypes {
+		if seatType == "" {
+			continue
+		}
+		shade := seatTypeToShade[seatType]
+		drawLegendEntry(seatType, Color{R: shade, G: shade, B: shade})
+	}
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetFillColor(255, 255, 255)
+	pdf.SetLineWidth(defaultLineWidth)
+	pdf.SetXY(leftMargin, legendY+4)
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package pdfgenerator
+
+import (
+	"NotificationService/internal/utils"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+	"go.uber.org/zap"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// TicketCardLayoutVersion identifies the drawing of the ticket cards, bump it whenever it changes.
+	TicketCardLayoutVersion = "ticket_card@1"
+
+	// Ticket cards are sized for phone screens, they are cut below their last element.
+	ticketCardWidth          = 720
+	ticketCardMaxHeight      = 1400
+	ticketCardMargin         = 48
+	ticketCardSpacing        = 24
+	ticketCardHeaderHeight   = 96
+	ticketCardPosterHeight   = 400
+	ticketCardQRCodeSize     = 360
+	ticketCardTitleMaxLines  = 2
+	ticketCardTitleFontSize  = 40
+	ticketCardHeaderFontSize = 28
+	ticketCardInfoFontSize   = 26
+	ticketCardSeatFontSize   = 56
+	ticketCardFooterFontSize = 20
+)
+
+var (
+	// ticketCardHeaderColor fills the header when the branding has no primary color.
+	ticketCardHeaderColor = Color{R: 33, G: 37, B: 41}
+	ticketCardMutedColor  = Color{R: 108, G: 117, B: 125}
+)
+
+// ticketCardGenerator renders the ticket of the params, which must have exactly one, as a PNG card to be shown
+// at the door from a phone.
+type ticketCardGenerator struct {
+	renderSlots renderSlots
+	fonts       fontChain
+	locale      string
+	logger      *zap.Logger
+}
+
+func (g ticketCardGenerator) Format() Format {
+	return FormatPNG
+}
+
+func (g ticketCardGenerator) Generate(ctx context.Context, params PDFGenerateParams) (PDFGenerateResult, error) {
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, params.BookingId), g.logger)
+
+	if len(params.Tickets) != 1 {
+		return PDFGenerateResult{}, fmt.Errorf("ticket card needs exactly one ticket, got %d", len(params.Tickets))
+	}
+	ticket := params.Tickets[0]
+
+	if err := g.renderSlots.acquire(ctx); err != nil {
+		return PDFGenerateResult{}, err
+	}
+	defer g.renderSlots.release()
+
+	location := params.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	headerColor := ticketCardHeaderColor
+	if params.Branding.PrimaryColor != nil {
+		headerColor = *params.Branding.PrimaryColor
+	}
+
+	card := &ticketCard{
+		img:   image.NewRGBA(image.Rect(0, 0, ticketCardWidth, ticketCardMaxHeight)),
+		fonts: g.fonts,
+	}
+	draw.Draw(card.img, card.img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	// Header
+	draw.Draw(card.img, image.Rect(0, 0, ticketCardWidth, ticketCardHeaderHeight), image.NewUniform(headerColor.rgba()), image.Point{}, draw.Src)
+	card.y = (ticketCardHeaderHeight - ticketCardHeaderFontSize) / 2
+	if err := card.drawText(params.TheaterName, fontStyleBold, ticketCardHeaderFontSize, Color{R: 255, G: 255, B: 255}, 1); err != nil {
+		return PDFGenerateResult{}, err
+	}
+	card.y = ticketCardHeaderHeight + ticketCardSpacing
+
+	if len(params.Poster) > 0 {
+		// A poster that cannot be decoded is left out rather than failing the card.
+		if err := card.drawPoster(params.Poster); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to draw poster on ticket card")
+		}
+	}
+
+	rows := []struct {
+		text      string
+		fontStyle string
+		fontSize  float64
+		color     Color
+		maxLines  int
+	}{
+		{params.MovieName, fontStyleBold, ticketCardTitleFontSize, Color{}, ticketCardTitleMaxLines},
+		{formatDateTime(time.UnixMilli(params.TimeStart).In(location), g.locale), fontStyleRegular, ticketCardInfoFontSize, Color{}, 1},
+		{params.ScreenName, fontStyleRegular, ticketCardInfoFontSize, ticketCardMutedColor, 1},
+		{ticket.SeatNo, fontStyleBold, ticketCardSeatFontSize, headerColor, 1},
+	}
+	for _, row := range rows {
+		if row.text == "" {
+			continue
+		}
+		if err := card.drawText(row.text, row.fontStyle, row.fontSize, row.color, row.maxLines); err != nil {
+			return PDFGenerateResult{}, err
+		}
+	}
+	card.y += ticketCardSpacing
+
+	if err := card.drawQRCode(ticket.TicketToken); err != nil {
+		return PDFGenerateResult{}, err
+	}
+
+	footer := fmt.Sprintf("Booking #%d", ticket.BookingId)
+	if err := card.drawText(footer, fontStyleRegular, ticketCardFooterFontSize, ticketCardMutedColor, 1); err != nil {
+		return PDFGenerateResult{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, card.img.SubImage(image.Rect(0, 0, ticketCardWidth, min(card.y+ticketCardMargin, ticketCardMaxHeight)))); err != nil {
+		logger.With(zap.Error(err)).Error("failed to encode ticket card")
+		return PDFGenerateResult{}, err
+	}
+
+	return PDFGenerateResult{
+		Data:          &buf,
+		LayoutVersion: TicketCardLayoutVersion,
+	}, nil
+}
+
+func (c Color) rgba() color.RGBA {
+	return color.RGBA{R: uint8(c.R), G: uint8(c.G), B: uint8(c.B), A: 0xFF}
+}
+
+// ticketCard draws the elements of a ticket card one below the other, horizontally centered. y is the top
+// of the next element.
+type ticketCard struct {
+	img   *image.RGBA
+	fonts fontChain
+	y     int
+}
+
+// drawPoster draws poster scaled down to fit the poster area, keeping its aspect ratio.
+func (c *ticketCard) drawPoster(poster []byte) error {
+	posterImage, _, err := image.Decode(bytes.NewReader(poster))
+	if err != nil {
+		return err
+	}
+
+	bounds := posterImage.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return fmt.Errorf("poster is empty")
+	}
+
+	maxWidth := ticketCardWidth - 2*ticketCardMargin
+	width, height := maxWidth, bounds.Dy()*maxWidth/bounds.Dx()
+	if height > ticketCardPosterHeight {
+		width, height = bounds.Dx()*ticketCardPosterHeight/bounds.Dy(), ticketCardPosterHeight
+	}
+
+	x := (ticketCardWidth - width) / 2
+	xdraw.CatmullRom.Scale(c.img, image.Rect(x, c.y, x+width, c.y+height), posterImage, bounds, draw.Over, nil)
+	c.y += height + ticketCardSpacing
+
+	return nil
+}
+
+func (c *ticketCard) drawQRCode(ticketToken string) error {
+	qrCode, err := qrcode.New(ticketToken, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	qrImage := qrCode.Image(ticketCardQRCodeSize)
+	x := (ticketCardWidth - ticketCardQRCodeSize) / 2
+	draw.Draw(c.img, image.Rect(x, c.y, x+ticketCardQRCodeSize, c.y+ticketCardQRCodeSize), qrImage, qrImage.Bounds().Min, draw.Src)
+	c.y += ticketCardQRCodeSize + ticketCardSpacing/2
+
+	return nil
+}
+
+// drawText draws text centered, wrapped at the margins on at most maxLines lines. The last line is cut with
+// an ellipsis if text does not fit.
+func (c *ticketCard) drawText(text string, fontStyle string, fontSize float64, textColor Color, maxLines int) error {
+	family, text := c.fonts.familyFor(text)
+	face, err := opentype.NewFace(family.styleToFont[fontStyle], &opentype.FaceOptions{
+		Size:    fontSize,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return err
+	}
+	defer face.Close()
+
+	drawer := &font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(textColor.rgba()),
+		Face: face,
+	}
+	metrics := face.Metrics()
+	for _, line := range wrapText(drawer, text, ticketCardWidth-2*ticketCardMargin, maxLines) {
+		x := (ticketCardWidth - drawer.MeasureString(line).Ceil()) / 2
+		drawer.Dot = fixed.P(x, c.y+metrics.Ascent.Ceil())
+		drawer.DrawString(line)
+		c.y += metrics.Height.Ceil()
+	}
+	c.y += ticketCardSpacing / 2
+
+	return nil
+}
+
+// wrapText splits text into lines of at most maxWidth pixels, breaking between words.
+func wrapText(drawer *font.Drawer, text string, maxWidth int, maxLines int) []string {
+	var (
+		lines []string
+		line  string
+	)
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line == "" || drawer.MeasureString(candidate).Ceil() <= maxWidth {
+			line = candidate
+			continue
+		}
+
+		lines = append(lines, line)
+		line = word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	if len(lines) <= maxLines {
+		return lines
+	}
+
+	lines = lines[:maxLines]
+	runes := []rune(lines[maxLines-1])
+	for len(runes) > 0 && drawer.MeasureString(string(runes)+"...").Ceil() > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	lines[maxLines-1] = string(runes) + "..."
+
+	return lines
+}
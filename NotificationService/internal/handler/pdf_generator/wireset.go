@@ -3,5 +3,5 @@ package pdfgenerator
 import "github.com/google/wire"
 
 var WireSet = wire.NewSet(
-	NewPDFGenerator,
+	NewRenderers,
 )
@@ -3,6 +3,8 @@ package handler
 import (
 	"NotificationService/internal/handler/consumers"
 	"NotificationService/internal/handler/grpc"
+	"NotificationService/internal/handler/http"
+	"NotificationService/internal/handler/jobs"
 	pdfGenerator "NotificationService/internal/handler/pdf_generator"
 
 	"github.com/google/wire"
@@ -10,6 +12,8 @@ import (
 
 var WireSet = wire.NewSet(
 	grpc.WireSet,
+	http.WireSet,
 	consumers.WireSet,
+	jobs.WireSet,
 	pdfGenerator.WireSet,
 )
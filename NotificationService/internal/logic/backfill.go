@@ -0,0 +1,260 @@
+package logic
+
+import (
+	"NotificationService/internal/dataaccess/database"
+	"NotificationService/internal/generated/booking_service"
+	"NotificationService/internal/generated/user_service"
+	"NotificationService/internal/utils"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultBackfillPageSize      = 100
+	defaultBackfillRatePerSecond = 5
+)
+
+type BackfillParams struct {
+	// ID names the checkpoint of the backfill, it is derived from the other params if empty so that running
+	// the same backfill again resumes it.
+	ID string
+	// UserIdList restricts the backfill to the bookings of these users, every user is enumerated if empty.
+	UserIdList []uint32
+	// FromTime and ToTime bound the booking time, they are ignored when zero.
+	FromTime time.Time
+	ToTime   time.Time
+	// SendEmail also emails the generated invoices, by default they are only stored.
+	SendEmail bool
+	// RatePerSecond is the maximum number of invoices generated per second.
+	RatePerSecond float64
+	// Restart ignores the checkpoint of a previous run.
+	Restart bool
+}
+
+type BackfillProgress struct {
+	ID                    string
+	UserOffset            uint32
+	UserCount             uint32
+	ProcessedBookingCount uint32
+	SkippedBookingCount   uint32
+	FailedBookingCount    uint32
+	Completed             bool
+}
+
+type BackfillLogic interface {
+	// Backfill generates the invoices of the confirmed bookings without notification, checkpointing after
+	// every user so that an interrupted backfill resumes where it stopped. progressFunc is called after
+	// every user.
+	Backfill(ctx context.Context, params BackfillParams, progressFunc func(BackfillProgress)) (BackfillProgress, error)
+}
+
+type backfillLogic struct {
+	notificationLogic              NotificationLogic
+	backfillCheckpointDataAccessor database.BackfillCheckpointDataAccessor
+	userServiceClient              user_service.UserServiceClient
+	bookingServiceClient           booking_service.BookingServiceClient
+	logger                         *zap.Logger
+}
+
+func NewBackfillLogic(
+	notificationLogic NotificationLogic,
+	backfillCheckpointDataAccessor database.BackfillCheckpointDataAccessor,
+	userServiceClient user_service.UserServiceClient,
+	bookingServiceClient booking_service.BookingServiceClient,
+	logger *zap.Logger,
+) BackfillLogic {
+	return &backfillLogic{
+		notificationLogic:              notificationLogic,
+		backfillCheckpointDataAccessor: backfillCheckpointDataAccessor,
+		userServiceClient:              userServiceClient,
+		bookingServiceClient:           bookingServiceClient,
+		logger:                         logger,
+	}
+}
+
+func backfillID(params BackfillParams) string {
+	if params.ID != "" {
+		return params.ID
+	}
+
+	hash := sha1.Sum([]byte(fmt.Sprintf(
+		"users=%v,from=%d,to=%d",
+		params.UserIdList,
+		params.FromTime.UnixMilli(),
+		params.ToTime.UnixMilli(),
+	)))
+	return "backfill-" + hex.EncodeToString(hash[:6])
+}
+
+func (b backfillLogic) Backfill(
+	ctx context.Context,
+	params BackfillParams,
+	progressFunc func(BackfillProgress),
+) (BackfillProgress, error) {
+	id := backfillID(params)
+	logger := utils.LoggerWithContext(ctx, b.logger).With(zap.String("backfill_id", id))
+
+	checkpoint, err := b.backfillCheckpointDataAccessor.GetBackfillCheckpoint(ctx, id)
+	if err != nil {
+		return BackfillProgress{}, err
+	}
+	if params.Restart {
+		checkpoint = &database.BackfillCheckpoint{ID: id}
+	}
+	if checkpoint.Completed {
+		logger.Info("backfill already completed")
+		return newBackfillProgress(checkpoint, 0), nil
+	}
+
+	ratePerSecond := params.RatePerSecond
+	if ratePerSecond <= 0 {
+		ratePerSecond = defaultBackfillRatePerSecond
+	}
+	limiter := rate.NewLimiter(rate.Limit(ratePerSecond), 1)
+
+	logger.With(zap.Uint32("next_user_offset", checkpoint.NextUserOffset)).Info("backfill started")
+
+	for {
+		userIdList, userCount, err := b.getUserIdPage(ctx, params.UserIdList, checkpoint.NextUserOffset)
+		if err != nil {
+			return newBackfillProgress(checkpoint, userCount), err
+		}
+		if len(userIdList) == 0 {
+			break
+		}
+
+		for _, userId := range userIdList {
+			if err := b.backfillUser(ctx, userId, params, limiter, checkpoint); err != nil {
+				return newBackfillProgress(checkpoint, userCount), err
+			}
+
+			checkpoint.NextUserOffset++
+			if err := b.backfillCheckpointDataAccessor.SaveBackfillCheckpoint(ctx, checkpoint); err != nil {
+				return newBackfillProgress(checkpoint, userCount), err
+			}
+
+			if progressFunc != nil {
+				progressFunc(newBackfillProgress(checkpoint, userCount))
+			}
+		}
+	}
+
+	checkpoint.Completed = true
+	if err := b.backfillCheckpointDataAccessor.SaveBackfillCheckpoint(ctx, checkpoint); err != nil {
+		return newBackfillProgress(checkpoint, 0), err
+	}
+
+	logger.
+		With(zap.Uint32("processed_booking_count", checkpoint.ProcessedBookingCount)).
+		With(zap.Uint32("skipped_booking_count", checkpoint.SkippedBookingCount)).
+		With(zap.Uint32("failed_booking_count", checkpoint.FailedBookingCount)).
+		Info("backfill completed")
+
+	return newBackfillProgress(checkpoint, checkpoint.NextUserOffset), nil
+}
+
+// getUserIdPage returns the page of user ids starting at offset, together with the total user count.
+func (b backfillLogic) getUserIdPage(ctx context.Context, userIdList []uint32, offset uint32) ([]uint32, uint32, error) {
+	if len(userIdList) > 0 {
+		userCount := uint32(len(userIdList))
+		if offset >= userCount {
+			return nil, userCount, nil
+		}
+
+		return userIdList[offset:min(offset+defaultBackfillPageSize, userCount)], userCount, nil
+	}
+
+	getUserListResp, err := b.userServiceClient.GetUserList(ctx, &user_service.GetUserListRequest{
+		Offset: offset,
+		Limit:  defaultBackfillPageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	userIdPage := make([]uint32, 0, len(getUserListResp.GetUserList()))
+	for _, user := range getUserListResp.GetUserList() {
+		userIdPage = append(userIdPage, user.GetId())
+	}
+
+	return userIdPage, getUserListResp.GetTotalUserCount(), nil
+}
+
+func (b backfillLogic) backfillUser(
+	ctx context.Context,
+	userId uint32,
+	params BackfillParams,
+	limiter *rate.Limiter,
+	checkpoint *database.BackfillCheckpoint,
+) error {
+	logger := utils.LoggerWithContext(ctx, b.logger).With(zap.Uint32(utils.LogFieldUserID, userId))
+
+	for offset := uint32(0); ; offset += defaultBackfillPageSize {
+		getBookingListResp, err := b.bookingServiceClient.GetBookingList(ctx, &booking_service.GetBookingListRequest{
+			UserId:        userId,
+			BookingStatus: booking_service.BookingStatus_CONFIRMED,
+			Offset:        offset,
+			Limit:         defaultBackfillPageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, bookingMetadata := range getBookingListResp.GetBookingList() {
+			booking := bookingMetadata.GetBooking()
+			if !inBookingTimeRange(booking, params.FromTime, params.ToTime) {
+				continue
+			}
+
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+
+			created, err := b.notificationLogic.BackfillNotification(ctx, booking, params.SendEmail)
+			switch {
+			case err != nil:
+				logger.With(zap.Uint32(utils.LogFieldBookingID, booking.GetId())).With(zap.Error(err)).Error("failed to backfill booking")
+				checkpoint.FailedBookingCount++
+			case created:
+				checkpoint.ProcessedBookingCount++
+			default:
+				checkpoint.SkippedBookingCount++
+			}
+		}
+
+		if len(getBookingListResp.GetBookingList()) < defaultBackfillPageSize {
+			return nil
+		}
+	}
+}
+
+func inBookingTimeRange(booking *booking_service.Booking, fromTime time.Time, toTime time.Time) bool {
+	// Booking time is in unix milliseconds.
+	bookingTime := time.UnixMilli(int64(booking.GetBookingTime()))
+	if !fromTime.IsZero() && bookingTime.Before(fromTime) {
+		return false
+	}
+	if !toTime.IsZero() && !bookingTime.Before(toTime) {
+		return false
+	}
+
+	return true
+}
+
+func newBackfillProgress(checkpoint *database.BackfillCheckpoint, userCount uint32) BackfillProgress {
+	return BackfillProgress{
+		ID:                    checkpoint.ID,
+		UserOffset:            checkpoint.NextUserOffset,
+		UserCount:             userCount,
+		ProcessedBookingCount: checkpoint.ProcessedBookingCount,
+		SkippedBookingCount:   checkpoint.SkippedBookingCount,
+		FailedBookingCount:    checkpoint.FailedBookingCount,
+		Completed:             checkpoint.Completed,
+	}
+}
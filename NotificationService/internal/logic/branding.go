@@ -0,0 +1,151 @@
+package logic
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/cache"
+	"NotificationService/internal/dataaccess/s3"
+	pdfgenerator "NotificationService/internal/handler/pdf_generator"
+	"NotificationService/internal/utils"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	cacheNamespaceBrandingLogo  = "branding_logo"
+	defaultBrandingLogoCacheTTL = time.Hour
+)
+
+type BrandingLogic interface {
+	// GetBranding returns the branding of the invoices of a theater, loading its logo from S3. Branding is
+	// cosmetic: a logo that cannot be loaded is logged and left out.
+	GetBranding(ctx context.Context, theaterId uint32) pdfgenerator.Branding
+}
+
+type brandingProfile struct {
+	name         string
+	layout       string
+	logoFilename string
+	primaryColor *pdfgenerator.Color
+	legalFooter  string
+}
+
+type brandingLogic struct {
+	defaultProfile     brandingProfile
+	theaterIdToProfile map[uint32]brandingProfile
+	logoCacheTTL       time.Duration
+	s3DM               s3.Client
+	cacheClient        cache.Client
+	logger             *zap.Logger
+}
+
+func NewBrandingLogic(
+	brandingConfig configs.Branding,
+	s3DM s3.Client,
+	cacheClient cache.Client,
+	logger *zap.Logger,
+) (BrandingLogic, error) {
+	defaultProfile, err := newBrandingProfile(brandingConfig.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	theaterIdToProfile := make(map[uint32]brandingProfile)
+	for _, profileConfig := range brandingConfig.Profiles {
+		profile, err := newBrandingProfile(profileConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, theaterId := range profileConfig.TheaterIds {
+			// The first profile listing a theater brands it.
+			if _, ok := theaterIdToProfile[theaterId]; !ok {
+				theaterIdToProfile[theaterId] = profile
+			}
+		}
+	}
+
+	logoCacheTTL := brandingConfig.LogoCacheTTL
+	if logoCacheTTL <= 0 {
+		logoCacheTTL = defaultBrandingLogoCacheTTL
+	}
+
+	return &brandingLogic{
+		defaultProfile:     defaultProfile,
+		theaterIdToProfile: theaterIdToProfile,
+		logoCacheTTL:       logoCacheTTL,
+		s3DM:               s3DM,
+		cacheClient:        cacheClient,
+		logger:             logger,
+	}, nil
+}
+
+func newBrandingProfile(profileConfig configs.BrandingProfile) (brandingProfile, error) {
+	profile := brandingProfile{
+		name:         profileConfig.Name,
+		layout:       profileConfig.Layout,
+		logoFilename: profileConfig.LogoFilename,
+		legalFooter:  profileConfig.LegalFooter,
+	}
+
+	if profileConfig.PrimaryColor != "" {
+		primaryColor, err := pdfgenerator.ParseColor(profileConfig.PrimaryColor)
+		if err != nil {
+			return brandingProfile{}, fmt.Errorf("invalid primary color of branding profile %s: %w", profileConfig.Name, err)
+		}
+		profile.primaryColor = &primaryColor
+	}
+
+	return profile, nil
+}
+
+func (b brandingLogic) GetBranding(ctx context.Context, theaterId uint32) pdfgenerator.Branding {
+	logger := utils.LoggerWithContext(ctx, b.logger).With(zap.Uint32("theater_id", theaterId))
+
+	profile, ok := b.theaterIdToProfile[theaterId]
+	if !ok {
+		profile = b.defaultProfile
+	}
+
+	branding := pdfgenerator.Branding{
+		Layout:       profile.layout,
+		PrimaryColor: profile.primaryColor,
+		LegalFooter:  profile.legalFooter,
+	}
+	if profile.logoFilename == "" {
+		return branding
+	}
+
+	logo, err := b.cacheClient.GetOrLoad(
+		ctx,
+		cacheNamespaceBrandingLogo,
+		profile.logoFilename,
+		b.logoCacheTTL,
+		func(ctx context.Context) ([]byte, error) {
+			return b.s3DM.GetFile(ctx, profile.logoFilename)
+		},
+	)
+	if err != nil {
+		logger.
+			With(zap.String("branding_profile", profile.name)).
+			With(zap.String("logo_filename", profile.logoFilename)).
+			With(zap.Error(err)).
+			Error("failed to get branding logo, rendering without it")
+		return branding
+	}
+
+	if contentType := http.DetectContentType(logo); contentType != "image/png" && contentType != "image/jpeg" {
+		logger.
+			With(zap.String("branding_profile", profile.name)).
+			With(zap.String("logo_filename", profile.logoFilename)).
+			With(zap.String("content_type", contentType)).
+			Error("branding logo is neither a PNG nor a JPEG image, rendering without it")
+		return branding
+	}
+	branding.Logo = logo
+
+	return branding
+}
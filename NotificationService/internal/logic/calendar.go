@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	calendarProductId = "-//Movie Ticket Booking//NotificationService//EN"
+	calendarFilename  = "showtime.ics"
+	// calendarMaxLineLength is the length in octets lines of an iCalendar file are folded at.
+	calendarMaxLineLength = 75
+
+	calendarDateTimeLayout    = "20060102T150405"
+	calendarUTCDateTimeLayout = "20060102T150405Z"
+)
+
+// MailShowtime is the showtime an email is about. TimeStart and TimeEnd are in the time zone of its theater.
+type MailShowtime struct {
+	MovieName       string
+	TheaterName     string
+	TheaterLocation string
+	ScreenName      string
+	TimeStart       time.Time
+	TimeEnd         time.Time
+}
+
+// newShowtimeCalendar returns an iCalendar file with a single event for showtime, whose times are in the time
+// zone of the theater. The time zone is described by its offset at the start of the showtime, which holds
+// for the whole event as it does not recur.
+func newShowtimeCalendar(showtime MailShowtime, uid string, now time.Time) []byte {
+	timeZoneId := showtime.TimeStart.Location().String()
+	timeZoneName, offset := showtime.TimeStart.Zone()
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + calendarProductId,
+		"METHOD:PUBLISH",
+		"BEGIN:VTIMEZONE",
+		"TZID:" + timeZoneId,
+		"BEGIN:STANDARD",
+		"DTSTART:19700101T000000",
+		"TZOFFSETFROM:" + formatCalendarUTCOffset(offset),
+		"TZOFFSETTO:" + formatCalendarUTCOffset(offset),
+		"TZNAME:" + escapeCalendarText(timeZoneName),
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:" + uid,
+		"DTSTAMP:" + now.UTC().Format(calendarUTCDateTimeLayout),
+		fmt.Sprintf("DTSTART;TZID=%s:%s", timeZoneId, showtime.TimeStart.Format(calendarDateTimeLayout)),
+		fmt.Sprintf("DTEND;TZID=%s:%s", timeZoneId, showtime.TimeEnd.In(showtime.TimeStart.Location()).Format(calendarDateTimeLayout)),
+		"SUMMARY:" + escapeCalendarText(showtime.MovieName),
+		"LOCATION:" + escapeCalendarText(fmt.Sprintf("%s, %s, %s", showtime.TheaterName, showtime.ScreenName, showtime.TheaterLocation)),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(foldCalendarLine(line))
+		builder.WriteString("\r\n")
+	}
+
+	return []byte(builder.String())
+}
+
+// formatCalendarUTCOffset formats an offset in seconds east of UTC as +hhmm.
+func formatCalendarUTCOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+func escapeCalendarText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// foldCalendarLine splits line into lines of at most calendarMaxLineLength octets, continued lines start with
+// a space. It never splits a UTF-8 sequence.
+func foldCalendarLine(line string) string {
+	var builder strings.Builder
+	lineLength := 0
+	for _, r := range line {
+		runeLength := len(string(r))
+		if lineLength+runeLength > calendarMaxLineLength {
+			builder.WriteString("\r\n ")
+			lineLength = 1
+		}
+		builder.WriteRune(r)
+		lineLength += runeLength
+	}
+
+	return builder.String()
+}
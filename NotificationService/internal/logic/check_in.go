@@ -0,0 +1,223 @@
+package logic
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/database"
+	"NotificationService/internal/generated/booking_service"
+	"NotificationService/internal/generated/movie_service"
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultCheckInOpensBeforeShowtimeStart = time.Hour
+	defaultCheckInClosesAfterShowtimeStart = 30 * time.Minute
+)
+
+var (
+	ErrInvalidTicket          = errors.New("invalid ticket")
+	ErrTicketAlreadyCheckedIn = errors.New("ticket is already checked in")
+	ErrBookingNotConfirmed    = errors.New("booking is not confirmed")
+	ErrOutsideCheckInWindow   = errors.New("showtime is not open for check-in")
+)
+
+type CheckInTicketParams struct {
+	TicketToken string
+	Gate        string
+	StaffId     uint32
+}
+
+type ShowtimeCheckInReport struct {
+	ShowtimeId            uint32
+	ConfirmedBookingCount int
+	// NotCheckedInBookingIdList are the confirmed bookings of the showtime that have not been checked in.
+	NotCheckedInBookingIdList []uint32
+	TicketCheckInList         []*database.TicketCheckIn
+}
+
+type CheckInLogic interface {
+	// CheckInTicket verifies the scanned ticket token against the state of its booking and records its
+	// check-in. A ticket can only be checked in once, within the check-in window of its showtime.
+	CheckInTicket(ctx context.Context, params CheckInTicketParams) (*database.TicketCheckIn, error)
+	GetShowtimeCheckInReport(ctx context.Context, showtimeId uint32) (ShowtimeCheckInReport, error)
+}
+
+type checkInLogic struct {
+	ticketLogic               TicketLogic
+	ticketCheckInDataAccessor database.TicketCheckInDataAccessor
+	bookingServiceClient      booking_service.BookingServiceClient
+	movieServiceClient        movie_service.MovieServiceClient
+	opensBeforeShowtimeStart  time.Duration
+	closesAfterShowtimeStart  time.Duration
+	logger                    *zap.Logger
+}
+
+func NewCheckInLogic(
+	ticketLogic TicketLogic,
+	ticketCheckInDataAccessor database.TicketCheckInDataAccessor,
+	bookingServiceClient booking_service.BookingServiceClient,
+	movieServiceClient movie_service.MovieServiceClient,
+	checkInConfig configs.CheckIn,
+	logger *zap.Logger,
+) CheckInLogic {
+	opensBeforeShowtimeStart := checkInConfig.OpensBeforeShowtimeStart
+	if opensBeforeShowtimeStart <= 0 {
+		opensBeforeShowtimeStart = defaultCheckInOpensBeforeShowtimeStart
+	}
+	closesAfterShowtimeStart := checkInConfig.ClosesAfterShowtimeStart
+	if closesAfterShowtimeStart <= 0 {
+		closesAfterShowtimeStart = defaultCheckInClosesAfterShowtimeStart
+	}
+
+	return &checkInLogic{
+		ticketLogic:               ticketLogic,
+		ticketCheckInDataAccessor: ticketCheckInDataAccessor,
+		bookingServiceClient:      bookingServiceClient,
+		movieServiceClient:        movieServiceClient,
+		opensBeforeShowtimeStart:  opensBeforeShowtimeStart,
+		closesAfterShowtimeStart:  closesAfterShowtimeStart,
+		logger:                    logger,
+	}
+}
+
+func (c checkInLogic) CheckInTicket(ctx context.Context, params CheckInTicketParams) (*database.TicketCheckIn, error) {
+	logger := utils.LoggerWithContext(ctx, c.logger).
+		With(zap.String("gate", params.Gate)).
+		With(zap.Uint32("staff_id", params.StaffId))
+
+	claims, err := c.ticketLogic.VerifyTicketToken(params.TicketToken)
+	if err != nil {
+		logger.With(zap.Error(err)).Warn("rejected ticket with invalid token")
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTicket, err)
+	}
+	ctx = utils.ContextWithBookingID(ctx, claims.BookingId)
+	logger = utils.LoggerWithContext(ctx, logger)
+
+	getBookingResp, err := c.bookingServiceClient.GetBookingById(
+		ctx,
+		&booking_service.GetBookingByIdRequest{BookingId: claims.BookingId},
+	)
+	if status.Code(err) == codes.NotFound {
+		logger.Warn("rejected ticket of unknown booking")
+		return nil, fmt.Errorf("%w: booking %d does not exist", ErrInvalidTicket, claims.BookingId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	booking := getBookingResp.GetBooking()
+	if booking.GetOfShowtimeId() != claims.ShowtimeId || booking.GetOfSeatId() != claims.SeatId {
+		// The booking was moved to another showtime or seat after the ticket was issued.
+		logger.Warn("rejected ticket that does not match its booking")
+		return nil, fmt.Errorf("%w: ticket does not match booking %d", ErrInvalidTicket, claims.BookingId)
+	}
+	if booking.GetBookingStatus() != booking_service.BookingStatus_CONFIRMED {
+		logger.With(zap.Stringer("booking_status", booking.GetBookingStatus())).Warn("rejected ticket of unconfirmed booking")
+		return nil, ErrBookingNotConfirmed
+	}
+
+	getShowtimeResp, err := c.movieServiceClient.GetShowtime(
+		ctx,
+		&movie_service.GetShowtimeRequest{ShowtimeId: claims.ShowtimeId},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	// Showtimes are in unix milliseconds.
+	showtimeStart := time.UnixMilli(getShowtimeResp.GetShowtime().GetTimeStart())
+	if now.Before(showtimeStart.Add(-c.opensBeforeShowtimeStart)) || now.After(showtimeStart.Add(c.closesAfterShowtimeStart)) {
+		logger.With(zap.Time("showtime_start", showtimeStart)).Warn("rejected ticket outside of check-in window")
+		return nil, fmt.Errorf(
+			"%w: check-in is open from %s to %s",
+			ErrOutsideCheckInWindow,
+			showtimeStart.Add(-c.opensBeforeShowtimeStart).Format(time.RFC3339),
+			showtimeStart.Add(c.closesAfterShowtimeStart).Format(time.RFC3339),
+		)
+	}
+
+	ticketCheckIn, err := c.ticketCheckInDataAccessor.CreateTicketCheckIn(ctx, &database.TicketCheckIn{
+		OfBookingId:  claims.BookingId,
+		OfShowtimeId: claims.ShowtimeId,
+		OfSeatId:     claims.SeatId,
+		Gate:         params.Gate,
+		StaffId:      params.StaffId,
+		CheckedInAt:  now,
+	})
+	if errors.Is(err, database.ErrTicketCheckInAlreadyExists) {
+		return nil, c.alreadyCheckedInError(ctx, claims.BookingId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("ticket checked in")
+	return ticketCheckIn, nil
+}
+
+// alreadyCheckedInError tells the staff when and where the ticket was first checked in, so that a replayed
+// ticket can be told apart from a guest scanning twice.
+func (c checkInLogic) alreadyCheckedInError(ctx context.Context, bookingId uint32) error {
+	logger := utils.LoggerWithContext(ctx, c.logger)
+
+	ticketCheckIn, err := c.ticketCheckInDataAccessor.GetTicketCheckInByBookingId(ctx, bookingId)
+	if err != nil {
+		logger.With(zap.Error(err)).Warn("rejected replayed ticket")
+		return ErrTicketAlreadyCheckedIn
+	}
+
+	logger.
+		With(zap.Time("checked_in_at", ticketCheckIn.CheckedInAt)).
+		With(zap.String("checked_in_gate", ticketCheckIn.Gate)).
+		Warn("rejected replayed ticket")
+
+	return fmt.Errorf(
+		"%w at %s through gate %s",
+		ErrTicketAlreadyCheckedIn,
+		ticketCheckIn.CheckedInAt.Format(time.RFC3339),
+		ticketCheckIn.Gate,
+	)
+}
+
+func (c checkInLogic) GetShowtimeCheckInReport(ctx context.Context, showtimeId uint32) (ShowtimeCheckInReport, error) {
+	report := ShowtimeCheckInReport{ShowtimeId: showtimeId}
+
+	getBookingListResp, err := c.bookingServiceClient.GetBookingListProcessingAndConfirmedByShowtimeId(
+		ctx,
+		&booking_service.GetBookingListProcessingAndConfirmedByShowtimeIdRequest{ShowtimeId: showtimeId},
+	)
+	if err != nil {
+		return report, err
+	}
+
+	report.TicketCheckInList, err = c.ticketCheckInDataAccessor.GetTicketCheckInListByShowtimeId(ctx, showtimeId)
+	if err != nil {
+		return report, err
+	}
+
+	checkedInBookingIdSet := make(map[uint32]bool, len(report.TicketCheckInList))
+	for _, ticketCheckIn := range report.TicketCheckInList {
+		checkedInBookingIdSet[ticketCheckIn.OfBookingId] = true
+	}
+
+	for _, booking := range getBookingListResp.GetBookingList() {
+		if booking.GetBookingStatus() != booking_service.BookingStatus_CONFIRMED {
+			continue
+		}
+
+		report.ConfirmedBookingCount++
+		if !checkedInBookingIdSet[booking.GetId()] {
+			report.NotCheckedInBookingIdList = append(report.NotCheckedInBookingIdList, booking.GetId())
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,31 @@
+package logic
+
+import (
+	"NotificationService/internal/dataaccess/database"
+	"NotificationService/internal/utils"
+	"context"
+)
+
+type DeliveryAttemptLogic interface {
+	// GetDeliveryAttemptList returns the timeline of the delivery attempts of a booking, oldest first.
+	GetDeliveryAttemptList(ctx context.Context, bookingId uint32) ([]*database.DeliveryAttempt, error)
+}
+
+type deliveryAttemptLogic struct {
+	deliveryAttemptDataAccessor database.DeliveryAttemptDataAccessor
+}
+
+func NewDeliveryAttemptLogic(
+	deliveryAttemptDataAccessor database.DeliveryAttemptDataAccessor,
+) DeliveryAttemptLogic {
+	return &deliveryAttemptLogic{
+		deliveryAttemptDataAccessor: deliveryAttemptDataAccessor,
+	}
+}
+
+func (d deliveryAttemptLogic) GetDeliveryAttemptList(
+	ctx context.Context,
+	bookingId uint32,
+) ([]*database.DeliveryAttempt, error) {
+	return d.deliveryAttemptDataAccessor.GetDeliveryAttemptListByBookingId(utils.ContextWithBookingID(ctx, bookingId), bookingId)
+}
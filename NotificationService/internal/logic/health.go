@@ -0,0 +1,204 @@
+package logic
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/database"
+	"NotificationService/internal/dataaccess/kafka/consumer"
+	"NotificationService/internal/dataaccess/s3"
+	"NotificationService/internal/handler/grpc/clients"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHealthCheckTimeout = 2 * time.Second
+	defaultHealthCacheTTL     = 5 * time.Second
+
+	defaultConsumerStallThreshold = 5 * time.Minute
+
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+var ErrNotConsumerGroupMember = errors.New("consumer is not a member of its consumer group")
+
+type HealthCheckResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Informational checks are reported without affecting the status of the report.
+	Informational bool      `json:"informational,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	CheckedAt     time.Time `json:"checked_at"`
+}
+
+type HealthReport struct {
+	Status string              `json:"status"`
+	Checks []HealthCheckResult `json:"checks,omitempty"`
+}
+
+func (r HealthReport) Healthy() bool {
+	return r.Status == HealthStatusUp
+}
+
+type HealthLogic interface {
+	// Live reports whether the service is running and its Kafka consumer makes progress on the messages it
+	// holds, so that a stuck consumer gets the service restarted. It does not check dependencies, nor whether
+	// the consumer is in its consumer group, so that an outage of one of them does not get the service restarted.
+	Live(ctx context.Context) HealthReport
+	// Ready checks every dependency and the consumer group membership concurrently, each within the check
+	// timeout. Results are reused for the cache TTL. The downstream services and the SMTP server are only informational: notifications waiting
+	// for them are deferred or retried, and an outage of one of them must not take every replica out of
+	// rotation at once.
+	Ready(ctx context.Context) HealthReport
+}
+
+type healthCheck struct {
+	name          string
+	check         func(ctx context.Context) error
+	informational bool
+}
+
+type healthLogic struct {
+	kafkaConsumer          consumer.Consumer
+	consumerStallThreshold time.Duration
+	checks                 []healthCheck
+	checkTimeout           time.Duration
+	cacheTTL               time.Duration
+	cacheMutex             sync.Mutex
+	cache                  map[string]HealthCheckResult
+	logger                 *zap.Logger
+}
+
+func NewHealthLogic(
+	healthConfig configs.Health,
+	db database.Database,
+	kafkaConsumer consumer.Consumer,
+	s3Client s3.Client,
+	mailer Mailer,
+	downstreamHealthChecker clients.HealthChecker,
+	logger *zap.Logger,
+) HealthLogic {
+	checkTimeout := healthConfig.CheckTimeout
+	if checkTimeout <= 0 {
+		checkTimeout = defaultHealthCheckTimeout
+	}
+
+	cacheTTL := healthConfig.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = defaultHealthCacheTTL
+	}
+
+	consumerStallThreshold := healthConfig.ConsumerStallThreshold
+	if consumerStallThreshold <= 0 {
+		consumerStallThreshold = defaultConsumerStallThreshold
+	}
+
+	checks := []healthCheck{
+		{name: "postgres", check: db.Ping},
+		{name: "kafka", check: kafkaConsumer.Ping},
+		{name: "kafka_consumer_group", check: func(context.Context) error {
+			if !kafkaConsumer.IsMember() {
+				return ErrNotConsumerGroupMember
+			}
+			return nil
+		}},
+		{name: "s3", check: s3Client.CreateBucketIfNotExist},
+		{name: "smtp", check: mailer.Ping, informational: true},
+	}
+	for _, serviceName := range downstreamHealthChecker.ServiceNameList() {
+		checks = append(checks, healthCheck{
+			name: serviceName,
+			check: func(ctx context.Context) error {
+				return downstreamHealthChecker.Check(ctx, serviceName)
+			},
+			informational: true,
+		})
+	}
+
+	return &healthLogic{
+		kafkaConsumer:          kafkaConsumer,
+		consumerStallThreshold: consumerStallThreshold,
+		checks:                 checks,
+		checkTimeout:           checkTimeout,
+		cacheTTL:               cacheTTL,
+		cache:                  make(map[string]HealthCheckResult),
+		logger:                 logger,
+	}
+}
+
+func (h *healthLogic) Live(ctx context.Context) HealthReport {
+	result := HealthCheckResult{
+		Name:      "kafka_consumer_progress",
+		Status:    HealthStatusUp,
+		CheckedAt: time.Now(),
+	}
+	if err := h.kafkaConsumer.CheckProgress(h.consumerStallThreshold); err != nil {
+		h.logger.With(zap.String("check", result.Name)).With(zap.Error(err)).Error("health check failed")
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+
+	return HealthReport{
+		Status: result.Status,
+		Checks: []HealthCheckResult{result},
+	}
+}
+
+func (h *healthLogic) Ready(ctx context.Context) HealthReport {
+	report := HealthReport{
+		Status: HealthStatusUp,
+		Checks: make([]HealthCheckResult, len(h.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = h.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != HealthStatusUp && !result.Informational {
+			report.Status = HealthStatusDown
+		}
+	}
+
+	return report
+}
+
+func (h *healthLogic) runCheck(ctx context.Context, check healthCheck) HealthCheckResult {
+	h.cacheMutex.Lock()
+	result, ok := h.cache[check.name]
+	h.cacheMutex.Unlock()
+	if ok && time.Since(result.CheckedAt) < h.cacheTTL {
+		return result
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+	defer cancel()
+
+	result = HealthCheckResult{
+		Name:          check.name,
+		Status:        HealthStatusUp,
+		Informational: check.informational,
+		CheckedAt:     time.Now(),
+	}
+	if err := check.check(checkCtx); err != nil {
+		h.logger.With(zap.String("check", check.name)).With(zap.Error(err)).Warn("health check failed")
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+
+	h.cacheMutex.Lock()
+	h.cache[check.name] = result
+	h.cacheMutex.Unlock()
+
+	return result
+}
@@ -0,0 +1,235 @@
+package logic
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/database"
+	"NotificationService/internal/generated/booking_service"
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultInvoiceTaxName            = "VAT"
+	defaultInvoiceTaxRateBasisPoints = 1000
+	defaultInvoiceTimeZone           = "Asia/Ho_Chi_Minh"
+
+	basisPointsPerUnit = 10000
+)
+
+type InvoiceLogic interface {
+	// IssueInvoice returns the invoice of bookings and its lines, issuing it with the next number of the issuer
+	// of theaterId if none of the bookings has one yet. The invoice has a line per booking, in order.
+	IssueInvoice(
+		ctx context.Context,
+		bookings []*booking_service.Booking,
+		theaterId uint32,
+	) (database.Invoice, []*database.InvoiceLine, error)
+	// VoidInvoice voids the invoice of bookingIds, if they have one, when they drop out of the group it was
+	// issued for, so that it no longer bills them and the bookings left are invoiced again.
+	VoidInvoice(ctx context.Context, bookingIds []uint32) error
+}
+
+type invoiceLogic struct {
+	invoiceDataAccessor database.InvoiceDataAccessor
+	defaultIssuer       configs.InvoiceIssuer
+	theaterIdToIssuer   map[uint32]configs.InvoiceIssuer
+	taxName             string
+	taxRateBasisPoints  uint32
+	pricesExcludeTax    bool
+	location            *time.Location
+	logger              *zap.Logger
+}
+
+func NewInvoiceLogic(
+	invoiceDataAccessor database.InvoiceDataAccessor,
+	invoiceConfig configs.Invoice,
+	logger *zap.Logger,
+) (InvoiceLogic, error) {
+	if invoiceConfig.DefaultIssuer.ID == "" {
+		logger.Warn("no default invoice issuer is configured, invoices of theaters without an issuer have no seller details")
+		invoiceConfig.DefaultIssuer.ID = "default"
+	}
+
+	theaterIdToIssuer := make(map[uint32]configs.InvoiceIssuer)
+	for _, issuer := range invoiceConfig.Issuers {
+		if issuer.ID == "" {
+			return nil, fmt.Errorf("invoice issuer %s has no id", issuer.Name)
+		}
+
+		for _, theaterId := range issuer.TheaterIds {
+			// The first issuer listing a theater issues its invoices.
+			if _, ok := theaterIdToIssuer[theaterId]; !ok {
+				theaterIdToIssuer[theaterId] = issuer
+			}
+		}
+	}
+
+	taxName := invoiceConfig.TaxName
+	taxRateBasisPoints := invoiceConfig.TaxRateBasisPoints
+	if taxName == "" {
+		taxName = defaultInvoiceTaxName
+		taxRateBasisPoints = defaultInvoiceTaxRateBasisPoints
+	}
+
+	timeZone := invoiceConfig.TimeZone
+	if timeZone == "" {
+		timeZone = defaultInvoiceTimeZone
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice time zone: %w", err)
+	}
+
+	return &invoiceLogic{
+		invoiceDataAccessor: invoiceDataAccessor,
+		defaultIssuer:       invoiceConfig.DefaultIssuer,
+		theaterIdToIssuer:   theaterIdToIssuer,
+		taxName:             taxName,
+		taxRateBasisPoints:  taxRateBasisPoints,
+		pricesExcludeTax:    invoiceConfig.PricesExcludeTax,
+		location:            location,
+		logger:              logger,
+	}, nil
+}
+
+func (i invoiceLogic) IssueInvoice(
+	ctx context.Context,
+	bookings []*booking_service.Booking,
+	theaterId uint32,
+) (database.Invoice, []*database.InvoiceLine, error) {
+	// The invoice of a group of bookings is filed under the first of them.
+	leadBooking := bookings[0]
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, leadBooking.Id), i.logger)
+
+	bookingIds := make([]uint32, 0, len(bookings))
+	for _, booking := range bookings {
+		bookingIds = append(bookingIds, booking.Id)
+	}
+
+	invoice, invoiceLines, err := i.getInvoice(ctx, bookingIds)
+	if err == nil {
+		return invoice, invoiceLines, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return database.Invoice{}, nil, err
+	}
+
+	issuer, ok := i.theaterIdToIssuer[theaterId]
+	if !ok {
+		issuer = i.defaultIssuer
+	}
+
+	series := issuer.Series
+	if series == "" {
+		series = issuer.ID
+	}
+
+	issuedAt := time.Now()
+	invoice = database.Invoice{
+		OfBookingId:        leadBooking.Id,
+		IssuerId:           issuer.ID,
+		Series:             series,
+		Year:               uint32(issuedAt.In(i.location).Year()),
+		IssuedAt:           issuedAt,
+		SellerName:         issuer.Name,
+		SellerTaxId:        issuer.TaxID,
+		SellerAddress:      issuer.Address,
+		Currency:           leadBooking.Currency,
+		TaxName:            i.taxName,
+		TaxRateBasisPoints: i.taxRateBasisPoints,
+	}
+
+	// Tax is rounded per line, the totals are the sums of the lines.
+	invoiceLines = make([]*database.InvoiceLine, 0, len(bookings))
+	for _, booking := range bookings {
+		netAmount, taxAmount, grossAmount := i.splitTax(booking.Amount)
+		invoiceLines = append(invoiceLines, &database.InvoiceLine{
+			OfBookingId: booking.Id,
+			NetAmount:   netAmount,
+			TaxAmount:   taxAmount,
+			GrossAmount: grossAmount,
+		})
+
+		invoice.NetAmount += netAmount
+		invoice.TaxAmount += taxAmount
+		invoice.GrossAmount += grossAmount
+	}
+
+	_, err = i.invoiceDataAccessor.CreateInvoice(ctx, &invoice, invoiceLines)
+	if errors.Is(err, database.ErrInvoiceAlreadyExists) {
+		// The invoice was issued concurrently, e.g. by a backfill.
+		return i.getInvoice(ctx, bookingIds)
+	}
+	if err != nil {
+		return database.Invoice{}, nil, err
+	}
+
+	logger.
+		With(zap.String("invoice_no", invoice.InvoiceNo())).
+		With(zap.Int("invoice_line_count", len(invoiceLines))).
+		Info("invoice issued")
+	return invoice, invoiceLines, nil
+}
+
+func (i invoiceLogic) VoidInvoice(ctx context.Context, bookingIds []uint32) error {
+	logger := utils.LoggerWithContext(ctx, i.logger).With(zap.Uint32s("booking_ids", bookingIds))
+
+	invoice, err := i.invoiceDataAccessor.GetInvoiceByBookingIds(ctx, bookingIds)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := i.invoiceDataAccessor.VoidInvoice(ctx, invoice.ID, time.Now()); err != nil {
+		return err
+	}
+
+	logger.With(zap.String("invoice_no", invoice.InvoiceNo())).Info("invoice voided")
+	return nil
+}
+
+// getInvoice returns the invoice of bookingIds with its lines. Invoices issued before invoices had lines are
+// returned with a single line covering the whole invoice.
+func (i invoiceLogic) getInvoice(ctx context.Context, bookingIds []uint32) (database.Invoice, []*database.InvoiceLine, error) {
+	invoice, err := i.invoiceDataAccessor.GetInvoiceByBookingIds(ctx, bookingIds)
+	if err != nil {
+		return database.Invoice{}, nil, err
+	}
+
+	invoiceLines, err := i.invoiceDataAccessor.GetInvoiceLineListByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		return database.Invoice{}, nil, err
+	}
+	if len(invoiceLines) == 0 {
+		invoiceLines = []*database.InvoiceLine{{
+			OfInvoiceId: invoice.ID,
+			OfBookingId: invoice.OfBookingId,
+			NetAmount:   invoice.NetAmount,
+			TaxAmount:   invoice.TaxAmount,
+			GrossAmount: invoice.GrossAmount,
+		}}
+	}
+
+	return *invoice, invoiceLines, nil
+}
+
+// splitTax returns the net, tax and gross amounts of a booking amount, rounded half up.
+func (i invoiceLogic) splitTax(amount uint64) (uint64, uint64, uint64) {
+	rate := uint64(i.taxRateBasisPoints)
+
+	if i.pricesExcludeTax {
+		taxAmount := (amount*rate + basisPointsPerUnit/2) / basisPointsPerUnit
+		return amount, taxAmount, amount + taxAmount
+	}
+
+	netAmount := (amount*basisPointsPerUnit + (basisPointsPerUnit+rate)/2) / (basisPointsPerUnit + rate)
+	return netAmount, amount - netAmount, amount
+}
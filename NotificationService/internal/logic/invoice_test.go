@@ -0,0 +1,104 @@
+package logic
+
+import "testing"
+
+func TestInvoiceLogicSplitTax(t *testing.T) {
+	testCases := []struct {
+		name               string
+		taxRateBasisPoints uint32
+		pricesExcludeTax   bool
+		amount             uint64
+		wantNetAmount      uint64
+		wantTaxAmount      uint64
+		wantGrossAmount    uint64
+	}{
+		{
+			name:               "price includes tax",
+			taxRateBasisPoints: 1000,
+			amount:             110000,
+			wantNetAmount:      100000,
+			wantTaxAmount:      10000,
+			wantGrossAmount:    110000,
+		},
+		{
+			name:               "price includes tax, net amount rounded down",
+			taxRateBasisPoints: 1000,
+			amount:             105,
+			wantNetAmount:      95,
+			wantTaxAmount:      10,
+			wantGrossAmount:    105,
+		},
+		{
+			name:               "price includes tax, net amount rounded up",
+			taxRateBasisPoints: 1000,
+			amount:             104,
+			wantNetAmount:      95,
+			wantTaxAmount:      9,
+			wantGrossAmount:    104,
+		},
+		{
+			name:               "price excludes tax",
+			taxRateBasisPoints: 1000,
+			pricesExcludeTax:   true,
+			amount:             100000,
+			wantNetAmount:      100000,
+			wantTaxAmount:      10000,
+			wantGrossAmount:    110000,
+		},
+		{
+			name:               "price excludes tax, half of a minor unit of tax rounded up",
+			taxRateBasisPoints: 1000,
+			pricesExcludeTax:   true,
+			amount:             15,
+			wantNetAmount:      15,
+			wantTaxAmount:      2,
+			wantGrossAmount:    17,
+		},
+		{
+			name:               "price excludes tax, less than half of a minor unit of tax rounded down",
+			taxRateBasisPoints: 1000,
+			pricesExcludeTax:   true,
+			amount:             14,
+			wantNetAmount:      14,
+			wantTaxAmount:      1,
+			wantGrossAmount:    15,
+		},
+		{
+			name:            "no tax",
+			amount:          12345,
+			wantNetAmount:   12345,
+			wantTaxAmount:   0,
+			wantGrossAmount: 12345,
+		},
+		{
+			name:               "zero amount",
+			taxRateBasisPoints: 800,
+			amount:             0,
+			wantNetAmount:      0,
+			wantTaxAmount:      0,
+			wantGrossAmount:    0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			i := invoiceLogic{
+				taxRateBasisPoints: testCase.taxRateBasisPoints,
+				pricesExcludeTax:   testCase.pricesExcludeTax,
+			}
+
+			netAmount, taxAmount, grossAmount := i.splitTax(testCase.amount)
+			if netAmount != testCase.wantNetAmount || taxAmount != testCase.wantTaxAmount || grossAmount != testCase.wantGrossAmount {
+				t.Errorf(
+					"splitTax(%d) = %d, %d, %d, want %d, %d, %d",
+					testCase.amount,
+					netAmount, taxAmount, grossAmount,
+					testCase.wantNetAmount, testCase.wantTaxAmount, testCase.wantGrossAmount,
+				)
+			}
+			if netAmount+taxAmount != grossAmount {
+				t.Errorf("splitTax(%d) net amount %d and tax amount %d do not add up to gross amount %d", testCase.amount, netAmount, taxAmount, grossAmount)
+			}
+		})
+	}
+}
@@ -6,39 +6,106 @@ import (
 	"NotificationService/internal/dataaccess/s3"
 	"NotificationService/internal/generated/booking_service"
 	"NotificationService/internal/generated/user_service"
+	"NotificationService/internal/metrics"
+	"NotificationService/internal/utils"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/textproto"
 	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gopkg.in/gomail.v2"
 )
 
 const (
+	smtpHost = "smtp.gmail.com"
+	smtpPort = 587
+
 	HeaderText                 = "Movie Ticket Booking Invoice!"
 	BodyTextWhenPaymentSuccess = "Payment transaction successfully, here is the attached PDF."
 	BodyTextWhenPaymentFailed  = "Payment transaction failed please retry if you need!!"
+
+	// EmailTemplateVersion is recorded with every delivery attempt, it has to be bumped whenever the subject,
+	// body or attachment of the email changes.
+	EmailTemplateVersion = "invoice-email/v3"
+
+	DeliveryErrorClassAttachment = "attachment"
+	DeliveryErrorClassTimeout    = "timeout"
+	DeliveryErrorClassConnection = "connection"
+	DeliveryErrorClassAuth       = "auth"
+	// DeliveryErrorClassTemporary is a 4xx SMTP reply, the same email may be accepted later.
+	DeliveryErrorClassTemporary = "temporary_rejection"
+	// DeliveryErrorClassPermanent is a 5xx SMTP reply, the same email will be rejected again.
+	DeliveryErrorClassPermanent = "permanent_rejection"
+	DeliveryErrorClassUnknown   = "unknown"
+
+	// ticketCardEmailWidth is the width ticket cards are shown at in the email body, half their pixel width
+	// so that they stay sharp on high density phone screens.
+	ticketCardEmailWidth = 360
+
+	// showtimeEmailLayout is the layout of the showtime in the email body, followed by its time zone.
+	showtimeEmailLayout = "Monday, 02 Jan 2006 15:04"
+
+	// providerResponseAccepted is recorded for a successful attempt, as gomail does not expose the reply
+	// of the SMTP server to an accepted email.
+	providerResponseAccepted = "accepted"
 )
 
 type Mailer interface {
+	// Send emails the PDF of notification. showtime is nil if the booking was not confirmed, the email then
+	// carries neither the showtime nor its calendar file.
 	Send(
 		ctx context.Context,
 		user *user_service.User,
 		booking *booking_service.Booking,
 		notification *database.Notification,
+		showtime *MailShowtime,
 	) error
+	// SendGroup sends one email with the shared PDF of the notifications of a group of confirmed bookings,
+	// recording a delivery attempt for each of them.
+	SendGroup(
+		ctx context.Context,
+		user *user_service.User,
+		notifications []*database.Notification,
+		showtime *MailShowtime,
+	) error
+	// Ping checks that the SMTP server accepts connections.
+	Ping(ctx context.Context) error
 }
 
 type mailer struct {
-	config configs.Mail
-	logger *zap.Logger
-	s3DM   s3.Client
+	config                      configs.Mail
+	tracer                      trace.Tracer
+	logger                      *zap.Logger
+	s3DM                        s3.Client
+	deliveryAttemptDataAccessor database.DeliveryAttemptDataAccessor
 }
 
-func NewMailer(config configs.Mail, logger *zap.Logger, s3DM s3.Client) Mailer {
+func NewMailer(
+	config configs.Mail,
+	tracerProvider trace.TracerProvider,
+	logger *zap.Logger,
+	s3DM s3.Client,
+	deliveryAttemptDataAccessor database.DeliveryAttemptDataAccessor,
+) Mailer {
 	return &mailer{
-		config: config,
-		logger: logger,
-		s3DM:   s3DM,
+		config:                      config,
+		tracer:                      tracerProvider.Tracer(tracerName),
+		logger:                      logger,
+		s3DM:                        s3DM,
+		deliveryAttemptDataAccessor: deliveryAttemptDataAccessor,
 	}
 }
 
@@ -47,41 +114,110 @@ func (m *mailer) Send(
 	user *user_service.User,
 	booking *booking_service.Booking,
 	notification *database.Notification,
+	showtime *MailShowtime,
+) error {
+	return m.send(ctx, user, booking.BookingStatus, []*database.Notification{notification}, showtime)
+}
+
+func (m *mailer) SendGroup(
+	ctx context.Context,
+	user *user_service.User,
+	notifications []*database.Notification,
+	showtime *MailShowtime,
+) error {
+	return m.send(ctx, user, booking_service.BookingStatus_CONFIRMED, notifications, showtime)
+}
+
+// send emails the PDF of the first of notifications, which all share it, along with the calendar file of
+// showtime.
+func (m *mailer) send(
+	ctx context.Context,
+	user *user_service.User,
+	bookingStatus booking_service.BookingStatus_Values,
+	notifications []*database.Notification,
+	showtime *MailShowtime,
 ) error {
-	logger := m.logger.With(zap.Any("send mail", notification.ID))
-	d := gomail.NewDialer("smtp.gmail.com", 587, m.config.HostEmail, m.config.HostEmailAppPassword)
+	logger := utils.LoggerWithContext(ctx, m.logger)
+	d := gomail.NewDialer(smtpHost, smtpPort, m.config.HostEmail, m.config.HostEmailAppPassword)
+
+	messageID := m.newMessageID()
+	deliveryAttempts := make([]*database.DeliveryAttempt, 0, len(notifications))
+	for _, notification := range notifications {
+		deliveryAttempts = append(deliveryAttempts, &database.DeliveryAttempt{
+			OfNotificationId: notification.ID,
+			OfBookingId:      notification.OfBookingId,
+			Channel:          database.DeliveryChannelEmail,
+			Recipient:        user.Email,
+			MessageID:        messageID,
+			TemplateVersion:  EmailTemplateVersion,
+			StartedAt:        time.Now(),
+		})
+	}
 
 	mail := gomail.NewMessage()
+	mail.SetHeader("Message-ID", messageID)
 	mail.SetHeader("From", m.config.HostEmail)
 	mail.SetHeader("To", user.Email)
 	mail.SetHeader("Subject", HeaderText)
 
 	// Set body text based on booking status
 	bodyText := BodyTextWhenPaymentSuccess
-	if booking.BookingStatus == booking_service.BookingStatus_CANCEL {
+	if bookingStatus == booking_service.BookingStatus_CANCEL {
 		bodyText = BodyTextWhenPaymentFailed
 	}
-	mail.SetBody("text/html", bodyText)
 
 	var tmpfile *os.File
-	if booking.BookingStatus != booking_service.BookingStatus_CANCEL {
+	if bookingStatus != booking_service.BookingStatus_CANCEL {
 		var err error
-		tmpfile, err = m.attachPDF(ctx, mail, notification)
+		tmpfile, err = m.attachPDF(ctx, mail, notifications[0])
 		if err != nil {
+			m.recordDeliveryAttempts(ctx, deliveryAttempts, DeliveryErrorClassAttachment, err)
 			return err
 		}
 		defer tmpfile.Close()
 		defer os.Remove(tmpfile.Name())
+
+		if showtime != nil {
+			bodyText += formatShowtimeEmailParagraph(*showtime)
+			m.attachShowtimeCalendar(mail, *showtime, notifications[0])
+		}
+
+		for _, ticketCardName := range m.embedTicketCards(ctx, mail, notifications) {
+			bodyText += fmt.Sprintf(`<p><img src="cid:%s" alt="Ticket" width="%d"></p>`, ticketCardName, ticketCardEmailWidth)
+		}
 	}
+	mail.SetBody("text/html", bodyText)
 
-	if err := d.DialAndSend(mail); err != nil {
+	_, span := m.tracer.Start(
+		ctx,
+		"smtp.DialAndSend",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("smtp.host", d.Host)),
+	)
+	sendStart := time.Now()
+	err := d.DialAndSend(mail)
+	metrics.ObserveDuration(metrics.SMTPSendDuration, sendStart, err)
+	utils.EndSpan(span, err)
+	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to send email")
+		m.recordDeliveryAttempts(ctx, deliveryAttempts, classifyDeliveryError(err), err)
 		return err
 	}
 
+	m.recordDeliveryAttempts(ctx, deliveryAttempts, "", nil)
 	return nil
 }
 
+func (m *mailer) Ping(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(smtpHost, strconv.Itoa(smtpPort)))
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
 func (m *mailer) attachPDF(ctx context.Context, mail *gomail.Message, notification *database.Notification) (*os.File, error) {
 	pdfData, err := m.s3DM.GetFile(ctx, notification.OriginalPDFFilename)
 	if err != nil {
@@ -106,3 +242,152 @@ func (m *mailer) attachPDF(ctx context.Context, mail *gomail.Message, notificati
 
 	return tmpfile, nil
 }
+
+// attachShowtimeCalendar attaches an iCalendar event for showtime, so that it can be added to a calendar in
+// the time zone of the theater.
+func (m *mailer) attachShowtimeCalendar(mail *gomail.Message, showtime MailShowtime, notification *database.Notification) {
+	// The event is named after the booking the PDF is filed under, so that a resent email updates it.
+	uid := fmt.Sprintf("booking-%d@%s", notification.OfBookingId, m.senderDomain())
+	calendarData := newShowtimeCalendar(showtime, uid, time.Now())
+
+	mail.Attach(
+		calendarFilename,
+		gomail.SetHeader(map[string][]string{"Content-Type": {"text/calendar; charset=UTF-8; method=PUBLISH"}}),
+		gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(calendarData)
+			return err
+		}),
+	)
+}
+
+// embedTicketCards inlines the ticket cards of notifications in mail, returning their content IDs. A ticket
+// card that cannot be read is left out, the PDF still carries every ticket.
+func (m *mailer) embedTicketCards(
+	ctx context.Context,
+	mail *gomail.Message,
+	notifications []*database.Notification,
+) []string {
+	ticketCardNames := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		if notification.TicketCardFilename == "" {
+			continue
+		}
+
+		ticketCardData, err := m.s3DM.GetFile(ctx, notification.TicketCardFilename)
+		if err != nil {
+			utils.LoggerWithContext(ctx, m.logger).
+				With(zap.String("ticket_card_filename", notification.TicketCardFilename)).
+				With(zap.Error(err)).
+				Warn("failed to get ticket card, will not inline it")
+			continue
+		}
+
+		// The content ID of an embedded file is its name.
+		ticketCardName := path.Base(notification.TicketCardFilename)
+		mail.Embed(ticketCardName, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(ticketCardData)
+			return err
+		}))
+		ticketCardNames = append(ticketCardNames, ticketCardName)
+	}
+
+	return ticketCardNames
+}
+
+// recordDeliveryAttempts stores the outcome of deliveryAttempts. Failing to record them does not fail the
+// delivery, since the email may already have been sent.
+func (m *mailer) recordDeliveryAttempts(
+	ctx context.Context,
+	deliveryAttempts []*database.DeliveryAttempt,
+	errorClass string,
+	err error,
+) {
+	for _, deliveryAttempt := range deliveryAttempts {
+		m.recordDeliveryAttempt(ctx, deliveryAttempt, errorClass, err)
+	}
+}
+
+func (m *mailer) recordDeliveryAttempt(
+	ctx context.Context,
+	deliveryAttempt *database.DeliveryAttempt,
+	errorClass string,
+	err error,
+) {
+	deliveryAttempt.FinishedAt = time.Now()
+	deliveryAttempt.Status = database.DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_SUCCESS
+	deliveryAttempt.ProviderResponse = providerResponseAccepted
+	if err != nil {
+		deliveryAttempt.Status = database.DeliveryAttemptStatus_DELIVERY_ATTEMPT_STATUS_FAILED
+		deliveryAttempt.ErrorClass = errorClass
+		deliveryAttempt.ProviderResponse = err.Error()
+	}
+
+	// The attempt is recorded even if the caller gave up on the delivery in the meantime.
+	if _, createErr := m.deliveryAttemptDataAccessor.CreateDeliveryAttempt(context.WithoutCancel(ctx), deliveryAttempt); createErr != nil {
+		utils.LoggerWithContext(ctx, m.logger).
+			With(zap.String("message_id", deliveryAttempt.MessageID)).
+			With(zap.Error(createErr)).
+			Warn("failed to record delivery attempt")
+	}
+}
+
+// newMessageID returns a Message-ID in the domain of the sender, so that the attempt can be matched against
+// the logs of the mail provider and the headers of the received email.
+func (m *mailer) newMessageID() string {
+	randomBytes := make([]byte, 16)
+	_, _ = rand.Read(randomBytes)
+
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(randomBytes), m.senderDomain())
+}
+
+func (m *mailer) senderDomain() string {
+	if at := strings.LastIndex(m.config.HostEmail, "@"); at >= 0 && at < len(m.config.HostEmail)-1 {
+		return m.config.HostEmail[at+1:]
+	}
+
+	return "notification-service"
+}
+
+// formatShowtimeEmailParagraph formats showtime for the email body, in the time zone of its theater.
+func formatShowtimeEmailParagraph(showtime MailShowtime) string {
+	return fmt.Sprintf(
+		"<p>%s<br>%s, %s<br>%s (%s)</p>",
+		html.EscapeString(showtime.MovieName),
+		html.EscapeString(showtime.TheaterName),
+		html.EscapeString(showtime.ScreenName),
+		showtime.TimeStart.Format(showtimeEmailLayout),
+		showtime.TimeStart.Location().String(),
+	)
+}
+
+func classifyDeliveryError(err error) string {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		switch {
+		case protoErr.Code == 530 || protoErr.Code == 534 || protoErr.Code == 535:
+			return DeliveryErrorClassAuth
+		case protoErr.Code >= 400 && protoErr.Code < 500:
+			return DeliveryErrorClassTemporary
+		case protoErr.Code >= 500:
+			return DeliveryErrorClassPermanent
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return DeliveryErrorClassTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return DeliveryErrorClassTimeout
+		}
+		return DeliveryErrorClassConnection
+	}
+	if errors.Is(err, io.EOF) {
+		// The server closed the connection before replying.
+		return DeliveryErrorClassConnection
+	}
+
+	return DeliveryErrorClassUnknown
+}
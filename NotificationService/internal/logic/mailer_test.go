@@ -0,0 +1,84 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"testing"
+)
+
+func TestClassifyDeliveryError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "authentication required",
+			err:  &textproto.Error{Code: 530, Msg: "Authentication Required"},
+			want: DeliveryErrorClassAuth,
+		},
+		{
+			name: "invalid credentials",
+			err:  &textproto.Error{Code: 535, Msg: "Username and Password not accepted"},
+			want: DeliveryErrorClassAuth,
+		},
+		{
+			name: "temporary rejection",
+			err:  &textproto.Error{Code: 451, Msg: "Temporary local problem"},
+			want: DeliveryErrorClassTemporary,
+		},
+		{
+			name: "permanent rejection",
+			err:  &textproto.Error{Code: 550, Msg: "Mailbox unavailable"},
+			want: DeliveryErrorClassPermanent,
+		},
+		{
+			name: "wrapped SMTP reply",
+			err:  fmt.Errorf("failed to send email: %w", &textproto.Error{Code: 421, Msg: "Service not available"}),
+			want: DeliveryErrorClassTemporary,
+		},
+		{
+			name: "context deadline exceeded",
+			err:  context.DeadlineExceeded,
+			want: DeliveryErrorClassTimeout,
+		},
+		{
+			name: "i/o deadline exceeded",
+			err:  fmt.Errorf("read: %w", os.ErrDeadlineExceeded),
+			want: DeliveryErrorClassTimeout,
+		},
+		{
+			name: "network timeout",
+			err:  &net.DNSError{Err: "i/o timeout", Name: "smtp.gmail.com", IsTimeout: true},
+			want: DeliveryErrorClassTimeout,
+		},
+		{
+			name: "connection refused",
+			err:  &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			want: DeliveryErrorClassConnection,
+		},
+		{
+			name: "connection closed by server",
+			err:  io.EOF,
+			want: DeliveryErrorClassConnection,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("gomail: could not send email"),
+			want: DeliveryErrorClassUnknown,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := classifyDeliveryError(testCase.err); got != testCase.want {
+				t.Errorf("classifyDeliveryError(%v) = %q, want %q", testCase.err, got, testCase.want)
+			}
+		})
+	}
+}
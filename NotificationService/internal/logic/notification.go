@@ -1,41 +1,101 @@
 package logic
 
 import (
+	"NotificationService/internal/configs"
 	"NotificationService/internal/dataaccess/database"
 	"NotificationService/internal/dataaccess/kafka/producer"
 	"NotificationService/internal/dataaccess/s3"
 	"NotificationService/internal/generated/booking_service"
 	"NotificationService/internal/generated/movie_service"
 	"NotificationService/internal/generated/user_service"
+	"NotificationService/internal/handler/grpc/clients/resilience"
 	pdfgenerator "NotificationService/internal/handler/pdf_generator"
+	"NotificationService/internal/metrics"
+	"NotificationService/internal/utils"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+const (
+	tracerName = "NotificationService/internal/logic"
+
+	defaultNotificationGroupWindow = time.Minute
+
+	defaultNotificationRetryDelay         = 30 * time.Second
+	defaultNotificationSchedulerBatchSize = 100
+)
+
+var (
+	ErrNotificationDeferred      = errors.New("notification deferred because a downstream service is unavailable")
+	ErrNotificationProcessing    = errors.New("notification is being processed")
+	ErrNotificationAlreadyExists = errors.New("notification already exists")
+	// ErrNotificationFailed is returned once the notification was marked as failed, sending it again takes
+	// resetting it to pending.
+	ErrNotificationFailed = errors.New("notification failed")
+
+	errNotificationGroupOpen     = errors.New("notification group is still open")
+	errNotificationGroupFinished = errors.New("notification group was already sent")
+)
+
 type NotificationLogic interface {
+	// CreateNotification creates the notification of a booking. The notification of a confirmed booking
+	// joins the open group of its user and showtime, which is created if there is none, and produces no
+	// notification created event: the group is enqueued by EnqueueDueNotifications once its window ends.
+	// It returns ErrNotificationAlreadyExists if the booking already has a notification.
 	CreateNotification(ctx context.Context, bookingId uint32) error
-	GeneratePDFAndSendEmail(ctx context.Context, bookingId uint32) error
+	// GeneratePDFAndSendEmail sends the notification, or the whole group of the notification once the group
+	// is closed, as one email with one PDF. It returns ErrNotificationFailed once it marked the notification
+	// as failed.
+	GeneratePDFAndSendEmail(ctx context.Context, notificationId uint32) error
+	// GetNotificationIdOfBooking returns the id of the notification of a booking.
+	GetNotificationIdOfBooking(ctx context.Context, bookingId uint32) (uint32, error)
+	// ResetNotificationToPending moves a finished notification back to pending, so that it is sent again
+	// the next time its notification created event is handled.
+	ResetNotificationToPending(ctx context.Context, notificationId uint32) error
+	// BackfillNotification generates and stores the invoice of a booking that has no notification yet,
+	// recording it as a sent notification. The invoice is only emailed if sendEmail is set. It returns
+	// false if the booking already has a notification.
+	BackfillNotification(ctx context.Context, booking *booking_service.Booking, sendEmail bool) (bool, error)
+	// EnqueueDueNotifications closes the groups whose window ended and produces the notification created
+	// events of their first notification and of the deferred notifications whose retry is due. It returns
+	// how many groups and notifications were enqueued.
+	EnqueueDueNotifications(ctx context.Context) (int, error)
 }
 
 type notificationLogic struct {
-	notificationDataAccessor    database.NotificationDataAccessor
-	pdfGenerator                pdfgenerator.PDFGenerator
-	mailer                      Mailer
-	s3DM                        s3.Client
-	notificationCreatedProducer producer.NotificationCreatedProducer
-	logger                      *zap.Logger
-	db                          *gorm.DB
-	userServiceClient           user_service.UserServiceClient
-	movieSerServiceClient       movie_service.MovieServiceClient
-	bookingSerServiceClient     booking_service.BookingServiceClient
+	notificationDataAccessor      database.NotificationDataAccessor
+	renderers                     pdfgenerator.Renderers
+	ticketLogic                   TicketLogic
+	mailer                        Mailer
+	s3DM                          s3.Client
+	notificationCreatedProducer   producer.NotificationCreatedProducer
+	logger                        *zap.Logger
+	db                            *gorm.DB
+	userServiceClient             user_service.UserServiceClient
+	movieSerServiceClient         movie_service.MovieServiceClient
+	bookingSerServiceClient       booking_service.BookingServiceClient
+	tracer                        trace.Tracer
+	brandingLogic                 BrandingLogic
+	invoiceLogic                  InvoiceLogic
+	theaterLogic                  TheaterLogic
+	notificationGroupDataAccessor database.NotificationGroupDataAccessor
+	notificationGroupConfig       configs.NotificationGroup
+	notificationSchedulerConfig   configs.NotificationScheduler
+	posterClient                  s3.PosterClient
 }
 
 func NewNotificationLogic(
 	notificationDataAccessor database.NotificationDataAccessor,
-	pdfGenerator pdfgenerator.PDFGenerator,
+	renderers pdfgenerator.Renderers,
+	ticketLogic TicketLogic,
 	mailer Mailer,
 	s3DM s3.Client,
 	notificationCreatedProducer producer.NotificationCreatedProducer,
@@ -44,23 +104,51 @@ func NewNotificationLogic(
 	userServiceClient user_service.UserServiceClient,
 	movieSerServiceClient movie_service.MovieServiceClient,
 	bookingSerServiceClient booking_service.BookingServiceClient,
+	tracerProvider trace.TracerProvider,
+	brandingLogic BrandingLogic,
+	invoiceLogic InvoiceLogic,
+	theaterLogic TheaterLogic,
+	notificationGroupDataAccessor database.NotificationGroupDataAccessor,
+	notificationGroupConfig configs.NotificationGroup,
+	notificationSchedulerConfig configs.NotificationScheduler,
+	posterClient s3.PosterClient,
 ) NotificationLogic {
+	if notificationGroupConfig.Window <= 0 {
+		notificationGroupConfig.Window = defaultNotificationGroupWindow
+	}
+	if notificationSchedulerConfig.RetryDelay <= 0 {
+		notificationSchedulerConfig.RetryDelay = defaultNotificationRetryDelay
+	}
+	if notificationSchedulerConfig.BatchSize <= 0 {
+		notificationSchedulerConfig.BatchSize = defaultNotificationSchedulerBatchSize
+	}
+
 	return &notificationLogic{
-		notificationDataAccessor:    notificationDataAccessor,
-		pdfGenerator:                pdfGenerator,
-		mailer:                      mailer,
-		s3DM:                        s3DM,
-		notificationCreatedProducer: notificationCreatedProducer,
-		logger:                      logger,
-		db:                          db,
-		userServiceClient:           userServiceClient,
-		movieSerServiceClient:       movieSerServiceClient,
-		bookingSerServiceClient:     bookingSerServiceClient,
+		notificationDataAccessor:      notificationDataAccessor,
+		renderers:                     renderers,
+		ticketLogic:                   ticketLogic,
+		mailer:                        mailer,
+		s3DM:                          s3DM,
+		notificationCreatedProducer:   notificationCreatedProducer,
+		logger:                        logger,
+		db:                            db,
+		userServiceClient:             userServiceClient,
+		movieSerServiceClient:         movieSerServiceClient,
+		bookingSerServiceClient:       bookingSerServiceClient,
+		tracer:                        tracerProvider.Tracer(tracerName),
+		brandingLogic:                 brandingLogic,
+		invoiceLogic:                  invoiceLogic,
+		theaterLogic:                  theaterLogic,
+		notificationGroupDataAccessor: notificationGroupDataAccessor,
+		notificationGroupConfig:       notificationGroupConfig,
+		notificationSchedulerConfig:   notificationSchedulerConfig,
+		posterClient:                  posterClient,
 	}
 }
 
 func (n *notificationLogic) CreateNotification(ctx context.Context, bookingId uint32) error {
-	logger := n.logger.With(zap.Any("create_notification_with_booking_id", bookingId))
+	ctx = utils.ContextWithBookingID(ctx, bookingId)
+	logger := utils.LoggerWithContext(ctx, n.logger)
 
 	notification := database.Notification{
 		OfBookingId:         bookingId,
@@ -68,99 +156,703 @@ func (n *notificationLogic) CreateNotification(ctx context.Context, bookingId ui
 		Status:              database.NotificationStatus_NOTIFICATION_STATUS_PENDING,
 	}
 
-	return n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	// Only confirmed bookings are grouped, a booking that cannot be fetched is notified on its own.
+	var groupedBooking *booking_service.Booking
+	if !n.notificationGroupConfig.Disabled {
+		booking, err := n.getBooking(ctx, bookingId)
+		if err != nil {
+			logger.With(zap.Error(err)).Warn("failed to get booking, will not group notification")
+		} else if booking.BookingStatus == booking_service.BookingStatus_CONFIRMED {
+			groupedBooking = &booking
+		}
+	}
+
+	err := n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		notificationCount, err := n.notificationDataAccessor.WithDB(tx).GetNotificationCount(ctx, bookingId)
 		if err != nil {
 			return err
 		}
 		if notificationCount > 0 {
-			logger.With(zap.Error(err)).Error("there is notification with booking_id in the database, will not create notification")
-			return fmt.Errorf("notification already exists for booking ID: %d", bookingId)
+			logger.Info("there is notification with booking_id in the database, will not create notification")
+			return fmt.Errorf("%w for booking ID: %d", ErrNotificationAlreadyExists, bookingId)
+		}
+
+		if groupedBooking != nil {
+			notificationGroup, err := n.getOrCreateOpenNotificationGroup(ctx, tx, groupedBooking)
+			if err != nil {
+				return err
+			}
+			notification.OfNotificationGroupId = notificationGroup.ID
 		}
 
 		if err := tx.Create(&notification).Error; err != nil {
 			return err
 		}
 
+		if notification.OfNotificationGroupId != 0 {
+			logger.With(zap.Uint32("notification_group_id", notification.OfNotificationGroupId)).Info("notification joined notification group")
+			return nil
+		}
+
 		if err := n.notificationCreatedProducer.Produce(
 			ctx,
-			producer.NotificationCreated{ID: notification.OfBookingId},
+			producer.NotificationCreated{ID: notification.ID, OfBookingId: notification.OfBookingId},
 		); err != nil {
 			return err
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	metrics.Notifications.WithLabelValues(metrics.NotificationTypeInvoice, metrics.ChannelEmail, metrics.NotificationEventCreated).Inc()
+	return nil
 }
 
-func (n notificationLogic) GeneratePDFAndSendEmail(ctx context.Context, notificationId uint32) error {
-	logger := n.logger.With(zap.Any("generate_pdf_and_send_email", notificationId))
+// GeneratePDFAndSendEmail traces each of its steps as a child span, so that a slow or failing step of a
+// ticket email can be told apart from the others.
+func (n notificationLogic) GeneratePDFAndSendEmail(ctx context.Context, notificationId uint32) (err error) {
+	ctx, span := n.tracer.Start(
+		ctx,
+		"GeneratePDFAndSendEmail",
+		trace.WithAttributes(attribute.Int64("notification.id", int64(notificationId))),
+	)
+	defer func() {
+		utils.EndSpan(span, err)
+	}()
 
-	updated, notification, err := n.updateNotificationFromPendingToProcessing(ctx, notificationId)
+	ctx = utils.ContextWithNotificationID(ctx, notificationId)
+	logger := utils.LoggerWithContext(ctx, n.logger)
+
+	stepCtx, stepSpan := n.tracer.Start(ctx, "update_notification_to_processing")
+	updated, notification, err := n.updateNotificationFromPendingToProcessing(stepCtx, notificationId)
+	utils.EndSpan(stepSpan, err)
 	if err != nil {
 		return err
 	}
 	if !updated {
 		return nil
 	}
+	ctx = utils.ContextWithBookingID(ctx, notification.OfBookingId)
+	logger = utils.LoggerWithContext(ctx, n.logger)
 
-	booking, err := n.getBooking(ctx, notification.OfBookingId)
+	if notification.OfNotificationGroupId != 0 {
+		span.SetAttributes(attribute.Int64("notification_group.id", int64(notification.OfNotificationGroupId)))
+		handled, err := n.sendNotificationGroup(ctx, notification)
+		if handled {
+			return err
+		}
+	}
+
+	stepCtx, stepSpan = n.tracer.Start(ctx, "get_booking")
+	booking, err := n.getBooking(stepCtx, notification.OfBookingId)
+	utils.EndSpan(stepSpan, err)
 	if err != nil {
-		n.updateNotificationStatusToFailed(ctx, *notification)
-		return err
+		return n.failOrDeferNotification(ctx, *notification, err)
 	}
+	span.SetAttributes(attribute.Int64("booking.id", int64(booking.Id)))
 
-	user, err := n.getUser(ctx, booking.OfUserId)
+	stepCtx, stepSpan = n.tracer.Start(ctx, "get_user")
+	user, err := n.getUser(stepCtx, booking.OfUserId)
+	utils.EndSpan(stepSpan, err)
 	if err != nil {
-		n.updateNotificationStatusToFailed(ctx, *notification)
-		return err
+		return n.failOrDeferNotification(ctx, *notification, err)
 	}
 
+	var showtime *MailShowtime
 	switch booking.BookingStatus {
 	case booking_service.BookingStatus_CANCEL:
 		break
 	case booking_service.BookingStatus_CONFIRMED:
-		originalPDFFilename, err := n.genPDF(ctx, &booking, &user)
+		stepCtx, stepSpan = n.tracer.Start(ctx, "generate_pdf")
+		files, err := n.genPDF(stepCtx, []*booking_service.Booking{&booking}, &user)
+		utils.EndSpan(stepSpan, err)
 		if err != nil {
-			n.updateNotificationStatusToFailed(ctx, *notification)
-			return err
+			return n.failOrDeferNotification(ctx, *notification, err)
 		}
-		notification.OriginalPDFFilename = originalPDFFilename
+		notification.OriginalPDFFilename = files.originalPDFFilename
+		notification.LayoutVersion = files.layoutVersion
+		notification.TicketCardFilename = files.bookingIdToTicketCardFilename[booking.Id]
+		showtime = files.showtime
 		_, err = n.notificationDataAccessor.UpdateNotification(ctx, notification)
 		if err != nil {
 			n.updateNotificationStatusToFailed(ctx, *notification)
-			return err
+			return fmt.Errorf("%w: %w", ErrNotificationFailed, err)
 		}
 	default:
-		logger.With(zap.Any("booking_status", booking.BookingStatus)).Error("unsupported booking status")
+		logger.With(zap.Stringer("booking_status", booking.BookingStatus)).Error("unsupported booking status")
 		n.updateNotificationStatusToFailed(ctx, *notification)
 		return nil
 	}
 
-	err = n.mailer.Send(ctx, &user, &booking, notification)
+	stepCtx, stepSpan = n.tracer.Start(ctx, "send_email")
+	err = n.mailer.Send(stepCtx, &user, &booking, notification, showtime)
+	utils.EndSpan(stepSpan, err)
 	if err != nil {
 		n.updateNotificationStatusToFailed(ctx, *notification)
-		return err
+		return fmt.Errorf("%w: %w", ErrNotificationFailed, err)
 	}
 
+	metrics.Notifications.WithLabelValues(metrics.NotificationTypeInvoice, metrics.ChannelEmail, metrics.NotificationEventSent).Inc()
+
+	stepCtx, stepSpan = n.tracer.Start(ctx, "update_notification_to_success")
 	notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_SUCCESS
-	_, err = n.notificationDataAccessor.UpdateNotification(ctx, notification)
-	if err != nil {
-		logger.With(zap.Error(err)).Warn("failed to update notification status to success")
+	_, updateErr := n.notificationDataAccessor.UpdateNotification(stepCtx, notification)
+	utils.EndSpan(stepSpan, updateErr)
+	if updateErr != nil {
+		logger.With(zap.Error(updateErr)).Warn("failed to update notification status to success")
 	}
 
-	logger.Info("notification successfully with booking_id",
-		zap.Uint32("booking_id", notification.OfBookingId))
+	logger.Info("notification sent successfully")
 
 	return nil
 }
 
+// sendNotificationGroup sends the group of notification, which is the notification being processed, once the
+// group is closed. A group is always sent as a whole, so that every email of the group carries its whole
+// invoice. It returns false, without sending anything, if the group was already sent: notification is then
+// detached from the group to be sent on its own.
+func (n notificationLogic) sendNotificationGroup(ctx context.Context, notification *database.Notification) (bool, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint32("notification_group_id", notification.OfNotificationGroupId))
+
+	stepCtx, stepSpan := n.tracer.Start(ctx, "close_notification_group")
+	notificationGroup, notifications, err := n.closeNotificationGroup(stepCtx, notification)
+	utils.EndSpan(stepSpan, err)
+	if errors.Is(err, errNotificationGroupOpen) {
+		logger.Info("notification group is still open, will send it once its window ends")
+		return true, nil
+	}
+	if errors.Is(err, errNotificationGroupFinished) {
+		logger.Info("notification group was already sent, will send notification on its own")
+		return false, nil
+	}
+	if err != nil {
+		n.updateNotificationStatusToFailed(ctx, *notification)
+		return true, fmt.Errorf("%w: %w", ErrNotificationFailed, err)
+	}
+
+	stepCtx, stepSpan = n.tracer.Start(ctx, "get_bookings")
+	bookings, err := n.getNotificationBookings(stepCtx, notifications)
+	utils.EndSpan(stepSpan, err)
+	if err != nil {
+		return true, n.failOrDeferNotificationGroup(ctx, notificationGroup, notifications, err)
+	}
+
+	stepCtx, stepSpan = n.tracer.Start(ctx, "detach_unconfirmed_notifications")
+	notifications, bookings, err = n.detachUnconfirmedNotifications(stepCtx, notifications, bookings)
+	utils.EndSpan(stepSpan, err)
+	if err != nil {
+		return true, n.failOrDeferNotificationGroup(ctx, notificationGroup, notifications, err)
+	}
+	if len(notifications) == 0 {
+		logger.Info("notification group has no confirmed booking left, will not send it")
+		n.updateNotificationGroupStatus(ctx, notificationGroup, database.NotificationStatus_NOTIFICATION_STATUS_SUCCESS)
+		return true, nil
+	}
+
+	stepCtx, stepSpan = n.tracer.Start(ctx, "get_user")
+	user, err := n.getUser(stepCtx, bookings[0].OfUserId)
+	utils.EndSpan(stepSpan, err)
+	if err != nil {
+		return true, n.failOrDeferNotificationGroup(ctx, notificationGroup, notifications, err)
+	}
+
+	stepCtx, stepSpan = n.tracer.Start(ctx, "generate_pdf")
+	files, err := n.genPDF(stepCtx, bookings, &user)
+	utils.EndSpan(stepSpan, err)
+	if err != nil {
+		return true, n.failOrDeferNotificationGroup(ctx, notificationGroup, notifications, err)
+	}
+	for _, groupNotification := range notifications {
+		groupNotification.OriginalPDFFilename = files.originalPDFFilename
+		groupNotification.LayoutVersion = files.layoutVersion
+		groupNotification.TicketCardFilename = files.bookingIdToTicketCardFilename[groupNotification.OfBookingId]
+		if _, err := n.notificationDataAccessor.UpdateNotification(ctx, groupNotification); err != nil {
+			n.updateNotificationGroupStatusToFailed(ctx, notificationGroup, notifications)
+			return true, fmt.Errorf("%w: %w", ErrNotificationFailed, err)
+		}
+	}
+
+	stepCtx, stepSpan = n.tracer.Start(ctx, "send_email")
+	err = n.mailer.SendGroup(stepCtx, &user, notifications, files.showtime)
+	utils.EndSpan(stepSpan, err)
+	if err != nil {
+		n.updateNotificationGroupStatusToFailed(ctx, notificationGroup, notifications)
+		return true, fmt.Errorf("%w: %w", ErrNotificationFailed, err)
+	}
+
+	stepCtx, stepSpan = n.tracer.Start(ctx, "update_notification_to_success")
+	for _, groupNotification := range notifications {
+		metrics.Notifications.WithLabelValues(metrics.NotificationTypeInvoice, metrics.ChannelEmail, metrics.NotificationEventSent).Inc()
+
+		groupNotification.Status = database.NotificationStatus_NOTIFICATION_STATUS_SUCCESS
+		if _, updateErr := n.notificationDataAccessor.UpdateNotification(stepCtx, groupNotification); updateErr != nil {
+			logger.With(zap.Error(updateErr)).Warn("failed to update notification status to success")
+		}
+	}
+	n.updateNotificationGroupStatus(stepCtx, notificationGroup, database.NotificationStatus_NOTIFICATION_STATUS_SUCCESS)
+	utils.EndSpan(stepSpan, nil)
+
+	logger.With(zap.Int("notification_count", len(notifications))).Info("notification group sent successfully")
+
+	return true, nil
+}
+
+// getOrCreateOpenNotificationGroup returns the open group of the user and showtime of booking, creating it if
+// there is none. It must be called in tx.
+func (n notificationLogic) getOrCreateOpenNotificationGroup(
+	ctx context.Context,
+	tx *gorm.DB,
+	booking *booking_service.Booking,
+) (*database.NotificationGroup, error) {
+	// Windows are stored without a time zone, in UTC.
+	now := time.Now().UTC()
+
+	notificationGroup, err := n.notificationGroupDataAccessor.WithDB(tx).GetOpenNotificationGroupWithXLock(
+		ctx,
+		booking.OfUserId,
+		booking.OfShowtimeId,
+		now,
+	)
+	if err == nil {
+		return notificationGroup, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	notificationGroup, err = n.notificationGroupDataAccessor.WithDB(tx).CreateNotificationGroup(ctx, &database.NotificationGroup{
+		OfUserId:     booking.OfUserId,
+		OfShowtimeId: booking.OfShowtimeId,
+		Status:       database.NotificationStatus_NOTIFICATION_STATUS_PENDING,
+		WindowEndsAt: now.Add(n.notificationGroupConfig.Window),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notificationGroup, nil
+}
+
+// closeNotificationGroup takes over the group of notification, which is the notification being processed, once
+// it was closed by EnqueueDueNotifications or deferred, moving its pending notifications to processing and
+// returning them. notification is returned in place of its stored copy. It returns errNotificationGroupOpen,
+// after moving notification back to pending, if the window of the group has not ended yet, and
+// errNotificationGroupFinished, after detaching notification from it, if the group was already sent.
+func (n notificationLogic) closeNotificationGroup(
+	ctx context.Context,
+	notification *database.Notification,
+) (*database.NotificationGroup, []*database.Notification, error) {
+	var (
+		notificationGroup *database.NotificationGroup
+		notifications     []*database.Notification
+		groupErr          error
+	)
+
+	err := n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		notificationGroup, err = n.notificationGroupDataAccessor.WithDB(tx).GetNotificationGroupByIdWithXLock(ctx, notification.OfNotificationGroupId)
+		if err != nil {
+			return err
+		}
+
+		switch notificationGroup.Status {
+		case database.NotificationStatus_NOTIFICATION_STATUS_PENDING:
+			// The event was redelivered or replayed before the window ended, the group is enqueued once it
+			// closes.
+			notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_PENDING
+			groupErr = errNotificationGroupOpen
+			_, err = n.notificationDataAccessor.WithDB(tx).UpdateNotification(ctx, notification)
+			return err
+		case database.NotificationStatus_NOTIFICATION_STATUS_SUCCESS, database.NotificationStatus_NOTIFICATION_STATUS_FAILED:
+			// The notification was reset or replayed after its group was sent.
+			notification.OfNotificationGroupId = 0
+			groupErr = errNotificationGroupFinished
+			_, err = n.notificationDataAccessor.WithDB(tx).UpdateNotification(ctx, notification)
+			return err
+		}
+
+		groupNotifications, err := n.notificationDataAccessor.WithDB(tx).GetNotificationListByGroupIdWithXLock(ctx, notificationGroup.ID)
+		if err != nil {
+			return err
+		}
+
+		notifications = make([]*database.Notification, 0, len(groupNotifications))
+		for _, groupNotification := range groupNotifications {
+			if groupNotification.ID == notification.ID {
+				notifications = append(notifications, notification)
+				continue
+			}
+
+			// The other notifications were already sent or are being processed by another worker.
+			if groupNotification.Status != database.NotificationStatus_NOTIFICATION_STATUS_PENDING {
+				continue
+			}
+
+			groupNotification.Status = database.NotificationStatus_NOTIFICATION_STATUS_PROCESSING
+			groupNotification.RetryAt = nil
+			if _, err := n.notificationDataAccessor.WithDB(tx).UpdateNotification(ctx, groupNotification); err != nil {
+				return err
+			}
+			notifications = append(notifications, groupNotification)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if groupErr != nil {
+		return nil, nil, groupErr
+	}
+
+	return notificationGroup, notifications, nil
+}
+
+// getNotificationBookings returns the bookings of notifications, in the same order.
+func (n notificationLogic) getNotificationBookings(
+	ctx context.Context,
+	notifications []*database.Notification,
+) ([]*booking_service.Booking, error) {
+	bookings := make([]*booking_service.Booking, 0, len(notifications))
+	for _, notification := range notifications {
+		booking, err := n.getBooking(ctx, notification.OfBookingId)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, &booking)
+	}
+
+	return bookings, nil
+}
+
+// detachUnconfirmedNotifications removes the notifications of the bookings that are no longer confirmed from
+// their group and enqueues them to be sent on their own, returning the notifications and bookings left. The
+// invoice already issued for the group, if any, is voided first so that it no longer bills these bookings.
+func (n notificationLogic) detachUnconfirmedNotifications(
+	ctx context.Context,
+	notifications []*database.Notification,
+	bookings []*booking_service.Booking,
+) ([]*database.Notification, []*booking_service.Booking, error) {
+	var (
+		confirmedNotifications = make([]*database.Notification, 0, len(notifications))
+		confirmedBookings      = make([]*booking_service.Booking, 0, len(bookings))
+		detachedNotifications  []*database.Notification
+	)
+	for i, notification := range notifications {
+		if bookings[i].BookingStatus == booking_service.BookingStatus_CONFIRMED {
+			confirmedNotifications = append(confirmedNotifications, notification)
+			confirmedBookings = append(confirmedBookings, bookings[i])
+			continue
+		}
+		detachedNotifications = append(detachedNotifications, notification)
+	}
+	if len(detachedNotifications) == 0 {
+		return notifications, bookings, nil
+	}
+
+	detachedBookingIds := make([]uint32, 0, len(detachedNotifications))
+	for _, notification := range detachedNotifications {
+		detachedBookingIds = append(detachedBookingIds, notification.OfBookingId)
+	}
+	if err := n.invoiceLogic.VoidInvoice(ctx, detachedBookingIds); err != nil {
+		return notifications, bookings, err
+	}
+
+	err := n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, notification := range detachedNotifications {
+			notification.OfNotificationGroupId = 0
+			notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_PENDING
+			if _, err := n.notificationDataAccessor.WithDB(tx).UpdateNotification(ctx, notification); err != nil {
+				return err
+			}
+
+			if err := n.notificationCreatedProducer.Produce(
+				ctx,
+				producer.NotificationCreated{ID: notification.ID, OfBookingId: notification.OfBookingId},
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		// The detached notifications are still in the group, which is failed or deferred as a whole.
+		for _, notification := range detachedNotifications {
+			notification.OfNotificationGroupId = notifications[0].OfNotificationGroupId
+		}
+		return notifications, bookings, err
+	}
+
+	utils.LoggerWithContext(ctx, n.logger).
+		With(zap.Int("detached_notification_count", len(detachedNotifications))).
+		Info("detached notifications of unconfirmed bookings from notification group")
+	return confirmedNotifications, confirmedBookings, nil
+}
+
+// failOrDeferNotificationGroup is failOrDeferNotification for the notifications of a group. A deferred group
+// stays processing, it is closed again when its first notification is retried.
+func (n notificationLogic) failOrDeferNotificationGroup(
+	ctx context.Context,
+	notificationGroup *database.NotificationGroup,
+	notifications []*database.Notification,
+	err error,
+) error {
+	if !resilience.IsCircuitOpen(err) {
+		n.updateNotificationGroupStatusToFailed(ctx, notificationGroup, notifications)
+		return fmt.Errorf("%w: %w", ErrNotificationFailed, err)
+	}
+
+	logger := utils.LoggerWithContext(ctx, n.logger)
+	logger.With(zap.Error(err)).Warn("downstream service is unavailable, deferring notification group")
+
+	retryAt := time.Now().UTC().Add(n.notificationSchedulerConfig.RetryDelay)
+	for i, notification := range notifications {
+		notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_PENDING
+		if i == 0 {
+			notification.RetryAt = &retryAt
+		}
+		if _, updateErr := n.notificationDataAccessor.UpdateNotification(ctx, notification); updateErr != nil {
+			logger.With(zap.Error(updateErr)).Warn("failed to update notification status to pending")
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w: %w", ErrNotificationDeferred, err)
+}
+
+func (n notificationLogic) updateNotificationGroupStatusToFailed(
+	ctx context.Context,
+	notificationGroup *database.NotificationGroup,
+	notifications []*database.Notification,
+) {
+	for _, notification := range notifications {
+		n.updateNotificationStatusToFailed(ctx, *notification)
+	}
+	n.updateNotificationGroupStatus(ctx, notificationGroup, database.NotificationStatus_NOTIFICATION_STATUS_FAILED)
+}
+
+func (n notificationLogic) updateNotificationGroupStatus(
+	ctx context.Context,
+	notificationGroup *database.NotificationGroup,
+	status database.NotificationStatus,
+) {
+	notificationGroup.Status = status
+	if _, err := n.notificationGroupDataAccessor.UpdateNotificationGroup(ctx, notificationGroup); err != nil {
+		utils.LoggerWithContext(ctx, n.logger).With(zap.Error(err)).Warn("failed to update notification group status")
+	}
+}
+
+func (n notificationLogic) GetNotificationIdOfBooking(ctx context.Context, bookingId uint32) (uint32, error) {
+	notification, err := n.notificationDataAccessor.GetNotificationByBookingId(ctx, bookingId)
+	if err != nil {
+		return 0, err
+	}
+
+	return notification.ID, nil
+}
+
+func (n notificationLogic) ResetNotificationToPending(ctx context.Context, notificationId uint32) error {
+	ctx = utils.ContextWithNotificationID(ctx, notificationId)
+	logger := utils.LoggerWithContext(ctx, n.logger)
+
+	return n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		notification, err := n.notificationDataAccessor.WithDB(tx).GetNotificationByIdWithXLock(ctx, notificationId)
+		if err != nil {
+			return err
+		}
+
+		switch notification.Status {
+		case database.NotificationStatus_NOTIFICATION_STATUS_PENDING:
+			return nil
+		case database.NotificationStatus_NOTIFICATION_STATUS_PROCESSING:
+			logger.Warn("notification is being processed, will not reset it")
+			return ErrNotificationProcessing
+		}
+
+		notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_PENDING
+		_, err = n.notificationDataAccessor.WithDB(tx).UpdateNotification(ctx, notification)
+		return err
+	})
+}
+
+func (n notificationLogic) BackfillNotification(
+	ctx context.Context,
+	booking *booking_service.Booking,
+	sendEmail bool,
+) (bool, error) {
+	ctx = utils.ContextWithBookingID(ctx, booking.Id)
+	logger := utils.LoggerWithContext(ctx, n.logger)
+
+	notificationCount, err := n.notificationDataAccessor.GetNotificationCount(ctx, booking.Id)
+	if err != nil {
+		return false, err
+	}
+	if notificationCount > 0 {
+		return false, nil
+	}
+
+	user, err := n.getUser(ctx, booking.OfUserId)
+	if err != nil {
+		return false, err
+	}
+
+	files, err := n.genPDF(ctx, []*booking_service.Booking{booking}, &user)
+	if err != nil {
+		return false, err
+	}
+
+	notification := &database.Notification{
+		OfBookingId:         booking.Id,
+		OriginalPDFFilename: files.originalPDFFilename,
+		LayoutVersion:       files.layoutVersion,
+		TicketCardFilename:  files.bookingIdToTicketCardFilename[booking.Id],
+		Status:              database.NotificationStatus_NOTIFICATION_STATUS_SUCCESS,
+	}
+	if _, err := n.notificationDataAccessor.CreateNotification(ctx, notification); err != nil {
+		return false, err
+	}
+	metrics.Notifications.WithLabelValues(metrics.NotificationTypeBackfill, metrics.ChannelEmail, metrics.NotificationEventCreated).Inc()
+	ctx = utils.ContextWithNotificationID(ctx, notification.ID)
+	logger = utils.LoggerWithContext(ctx, n.logger)
+
+	if sendEmail {
+		if err := n.mailer.Send(ctx, &user, booking, notification, files.showtime); err != nil {
+			logger.With(zap.Error(err)).Error("failed to send backfilled notification")
+			metrics.Notifications.WithLabelValues(metrics.NotificationTypeBackfill, metrics.ChannelEmail, metrics.NotificationEventFailed).Inc()
+
+			notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_FAILED
+			if _, updateErr := n.notificationDataAccessor.UpdateNotification(ctx, notification); updateErr != nil {
+				logger.With(zap.Error(updateErr)).Warn("failed to update notification status to failed")
+			}
+			return true, err
+		}
+		metrics.Notifications.WithLabelValues(metrics.NotificationTypeBackfill, metrics.ChannelEmail, metrics.NotificationEventSent).Inc()
+	}
+
+	return true, nil
+}
+
+func (n notificationLogic) EnqueueDueNotifications(ctx context.Context) (int, error) {
+	closedCount, err := n.closeDueNotificationGroups(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	retriedCount, err := n.enqueueDeferredNotifications(ctx)
+	if err != nil {
+		return closedCount, err
+	}
+
+	return closedCount + retriedCount, nil
+}
+
+// closeDueNotificationGroups moves the pending groups whose window ended to processing, so that no booking
+// joins them anymore, and enqueues the first pending notification of each, whose handling sends the group.
+func (n notificationLogic) closeDueNotificationGroups(ctx context.Context) (int, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger)
+
+	closedCount := 0
+	err := n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		notificationGroups, err := n.notificationGroupDataAccessor.WithDB(tx).GetDueNotificationGroupListWithXLock(
+			ctx,
+			time.Now().UTC(),
+			n.notificationSchedulerConfig.BatchSize,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, notificationGroup := range notificationGroups {
+			notificationGroup.Status = database.NotificationStatus_NOTIFICATION_STATUS_PROCESSING
+			if _, err := n.notificationGroupDataAccessor.WithDB(tx).UpdateNotificationGroup(ctx, notificationGroup); err != nil {
+				return err
+			}
+
+			notifications, err := n.notificationDataAccessor.WithDB(tx).GetNotificationListByGroupIdWithXLock(ctx, notificationGroup.ID)
+			if err != nil {
+				return err
+			}
+
+			leadIndex := slices.IndexFunc(notifications, func(notification *database.Notification) bool {
+				return notification.Status == database.NotificationStatus_NOTIFICATION_STATUS_PENDING
+			})
+			if leadIndex < 0 {
+				logger.With(zap.Uint32("notification_group_id", notificationGroup.ID)).Warn("notification group has no pending notification, will not enqueue it")
+				continue
+			}
+
+			if err := n.notificationCreatedProducer.Produce(
+				ctx,
+				producer.NotificationCreated{
+					ID:          notifications[leadIndex].ID,
+					OfBookingId: notifications[leadIndex].OfBookingId,
+				},
+			); err != nil {
+				return err
+			}
+		}
+
+		closedCount = len(notificationGroups)
+		return nil
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to close due notification groups")
+		return 0, err
+	}
+
+	return closedCount, nil
+}
+
+// enqueueDeferredNotifications enqueues the deferred notifications whose retry is due.
+func (n notificationLogic) enqueueDeferredNotifications(ctx context.Context) (int, error) {
+	logger := utils.LoggerWithContext(ctx, n.logger)
+
+	enqueuedCount := 0
+	err := n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		notifications, err := n.notificationDataAccessor.WithDB(tx).GetDueNotificationListWithXLock(
+			ctx,
+			time.Now().UTC(),
+			n.notificationSchedulerConfig.BatchSize,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, notification := range notifications {
+			notification.RetryAt = nil
+			if _, err := n.notificationDataAccessor.WithDB(tx).UpdateNotification(ctx, notification); err != nil {
+				return err
+			}
+
+			if err := n.notificationCreatedProducer.Produce(
+				ctx,
+				producer.NotificationCreated{ID: notification.ID, OfBookingId: notification.OfBookingId},
+			); err != nil {
+				return err
+			}
+		}
+
+		enqueuedCount = len(notifications)
+		return nil
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to enqueue deferred notifications")
+		return 0, err
+	}
+
+	return enqueuedCount, nil
+}
+
 func (n notificationLogic) updateNotificationFromPendingToProcessing(
 	ctx context.Context,
 	notificationId uint32,
 ) (bool, *database.Notification, error) {
 	var (
-		logger       = n.logger.With(zap.Any("update_notification_from_pending_to_processing", notificationId))
+		logger       = utils.LoggerWithContext(ctx, n.logger)
 		updated      = false
 		notification *database.Notification
 		err          error
@@ -178,7 +870,9 @@ func (n notificationLogic) updateNotificationFromPendingToProcessing(
 			return nil
 		}
 
+		// A deferred notification whose event arrives before its retry is due is not enqueued again.
 		notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_PROCESSING
+		notification.RetryAt = nil
 		_, err = n.notificationDataAccessor.WithDB(tx).UpdateNotification(ctx, notification)
 		if err != nil {
 			return err
@@ -194,8 +888,37 @@ func (n notificationLogic) updateNotificationFromPendingToProcessing(
 	return updated, notification, nil
 }
 
+// failOrDeferNotification marks the notification as failed, unless err was caused by an open circuit breaker,
+// in which case the notification is moved back to pending and scheduled to be enqueued again after the retry
+// delay.
+func (n notificationLogic) failOrDeferNotification(
+	ctx context.Context,
+	notification database.Notification,
+	err error,
+) error {
+	if !resilience.IsCircuitOpen(err) {
+		n.updateNotificationStatusToFailed(ctx, notification)
+		return fmt.Errorf("%w: %w", ErrNotificationFailed, err)
+	}
+
+	logger := utils.LoggerWithContext(ctx, n.logger)
+	logger.With(zap.Error(err)).Warn("downstream service is unavailable, deferring notification")
+
+	retryAt := time.Now().UTC().Add(n.notificationSchedulerConfig.RetryDelay)
+	notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_PENDING
+	notification.RetryAt = &retryAt
+	if _, updateErr := n.notificationDataAccessor.UpdateNotification(ctx, &notification); updateErr != nil {
+		logger.With(zap.Error(updateErr)).Warn("failed to update notification status to pending")
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrNotificationDeferred, err)
+}
+
 func (n notificationLogic) updateNotificationStatusToFailed(ctx context.Context, notification database.Notification) {
-	logger := n.logger.With(zap.Any("update_notification_status_to_failed", notification.ID))
+	logger := utils.LoggerWithContext(ctx, n.logger)
+
+	metrics.Notifications.WithLabelValues(metrics.NotificationTypeInvoice, metrics.ChannelEmail, metrics.NotificationEventFailed).Inc()
 
 	notification.Status = database.NotificationStatus_NOTIFICATION_STATUS_FAILED
 	_, err := n.notificationDataAccessor.UpdateNotification(ctx, &notification)
@@ -205,7 +928,7 @@ func (n notificationLogic) updateNotificationStatusToFailed(ctx context.Context,
 }
 
 func (n notificationLogic) getUser(ctx context.Context, userId uint32) (user_service.User, error) {
-	logger := n.logger.With(zap.Any("get user", userId))
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint32(utils.LogFieldUserID, userId))
 
 	getUserResp, getUserErr := n.userServiceClient.GetUser(
 		ctx,
@@ -219,52 +942,219 @@ func (n notificationLogic) getUser(ctx context.Context, userId uint32) (user_ser
 	return *getUserResp.GetUser(), nil
 }
 
+// renderedFiles are the files rendered for a set of bookings and stored in S3.
+type renderedFiles struct {
+	originalPDFFilename string
+	// layoutVersion is the version of the layout the PDF was rendered with.
+	layoutVersion string
+	// bookingIdToTicketCardFilename misses the bookings whose ticket card could not be rendered.
+	bookingIdToTicketCardFilename map[uint32]string
+	showtime                      *MailShowtime
+}
+
+// genPDF renders and uploads the invoice of bookings, which are for the same showtime, and the ticket card of
+// each booking. The PDF is filed under the first booking. Ticket cards are a convenience, failing to render
+// one does not fail the PDF.
 func (n notificationLogic) genPDF(
 	ctx context.Context,
-	booking *booking_service.Booking,
+	bookings []*booking_service.Booking,
 	user *user_service.User,
-) (string, error) {
-	originalPDFFilename := fmt.Sprintf("pdf_invoice_%d.pdf", booking.Id)
+) (renderedFiles, error) {
+	pdfRenderer, ok := n.renderers.Get(pdfgenerator.FormatPDF)
+	if !ok {
+		return renderedFiles{}, errors.New("no pdf renderer is registered")
+	}
 
-	showtimeMetadata, err := n.getShowtimeMetadata(ctx, booking.OfShowtimeId)
+	leadBooking := bookings[0]
+	originalPDFFilename := fmt.Sprintf("pdf_invoice_%d%s", leadBooking.Id, pdfgenerator.FormatPDF.Extension())
+
+	showtimeMetadata, err := n.getShowtimeMetadata(ctx, leadBooking.OfShowtimeId)
 	if err != nil {
-		return "", err
+		return renderedFiles{}, err
 	}
 
-	seat, err := n.getSeat(ctx, booking.OfSeatId)
+	branding := n.brandingLogic.GetBranding(ctx, showtimeMetadata.Theater.Id)
+
+	invoice, invoiceLines, err := n.invoiceLogic.IssueInvoice(ctx, bookings, showtimeMetadata.Theater.Id)
 	if err != nil {
-		return "", err
+		return renderedFiles{}, err
 	}
 
-	fileData, err := n.pdfGenerator.Generate(pdfgenerator.PDFGenerateParams{
+	tickets := make([]pdfgenerator.PDFTicket, 0, len(bookings))
+	bookedSeatIdSet := make(map[uint32]bool, len(bookings))
+	for _, booking := range bookings {
+		seat, err := n.getSeat(ctx, booking.OfSeatId)
+		if err != nil {
+			return renderedFiles{}, err
+		}
+
+		// Showtimes are in unix milliseconds.
+		ticketToken, err := n.ticketLogic.IssueTicketToken(booking, time.UnixMilli(showtimeMetadata.Showtime.TimeEnd))
+		if err != nil {
+			return renderedFiles{}, err
+		}
+
+		tickets = append(tickets, pdfgenerator.PDFTicket{
+			BookingId:   booking.Id,
+			SeatNo:      seat.No,
+			Amount:      booking.Amount,
+			TicketToken: ticketToken,
+		})
+		bookedSeatIdSet[booking.OfSeatId] = true
+	}
+
+	pdfInvoiceLines := make([]pdfgenerator.InvoiceLine, 0, len(invoiceLines))
+	for _, invoiceLine := range invoiceLines {
+		pdfInvoiceLines = append(pdfInvoiceLines, pdfgenerator.InvoiceLine{
+			BookingId:   invoiceLine.OfBookingId,
+			NetAmount:   invoiceLine.NetAmount,
+			TaxAmount:   invoiceLine.TaxAmount,
+			GrossAmount: invoiceLine.GrossAmount,
+		})
+	}
+
+	location := n.theaterLogic.GetLocation(showtimeMetadata.Theater.Id)
+	renderCtx, renderSpan := n.tracer.Start(ctx, "render_pdf")
+	generateStart := time.Now()
+	generateParams := pdfgenerator.PDFGenerateParams{
+		BookingId:       leadBooking.Id,
 		Username:        user.Username,
 		Email:           user.Email,
-		Amount:          booking.Amount,
-		Currency:        booking.Currency,
+		Currency:        leadBooking.Currency,
 		MovieName:       showtimeMetadata.Movie.Title,
-		SeatNo:          seat.No,
 		TheaterName:     showtimeMetadata.Theater.DisplayName,
 		TheaterLocation: showtimeMetadata.Theater.Location,
 		ScreenName:      showtimeMetadata.Screen.DisplayName,
 		TimeStart:       showtimeMetadata.Showtime.TimeStart,
 		TimeEnd:         showtimeMetadata.Showtime.TimeEnd,
-	})
+		Location:        location,
+		Tickets:         tickets,
+		Invoice: &pdfgenerator.Invoice{
+			InvoiceNo:          invoice.InvoiceNo(),
+			IssuedAt:           invoice.IssuedAt,
+			SellerName:         invoice.SellerName,
+			SellerTaxId:        invoice.SellerTaxId,
+			SellerAddress:      invoice.SellerAddress,
+			TaxName:            invoice.TaxName,
+			TaxRateBasisPoints: invoice.TaxRateBasisPoints,
+			NetAmount:          invoice.NetAmount,
+			TaxAmount:          invoice.TaxAmount,
+			GrossAmount:        invoice.GrossAmount,
+			Lines:              pdfInvoiceLines,
+		},
+		SeatMap:  newSeatMap(&showtimeMetadata, bookedSeatIdSet),
+		Branding: branding,
+	}
+	generateResult, err := pdfRenderer.Generate(renderCtx, generateParams)
+	metrics.ObserveDuration(metrics.PDFGenerationDuration, generateStart, err)
+	utils.EndSpan(renderSpan, err)
+	if err != nil {
+		return renderedFiles{}, err
+	}
+
+	if err := n.s3DM.UploadFile(ctx, originalPDFFilename, generateResult.Data); err != nil {
+		return renderedFiles{}, err
+	}
+
+	generateParams.Poster = n.getMoviePoster(ctx, showtimeMetadata.Movie)
+
+	return renderedFiles{
+		originalPDFFilename:           originalPDFFilename,
+		layoutVersion:                 generateResult.LayoutVersion,
+		bookingIdToTicketCardFilename: n.genTicketCards(ctx, generateParams),
+		showtime: &MailShowtime{
+			MovieName:       showtimeMetadata.Movie.Title,
+			TheaterName:     showtimeMetadata.Theater.DisplayName,
+			TheaterLocation: showtimeMetadata.Theater.Location,
+			ScreenName:      showtimeMetadata.Screen.DisplayName,
+			TimeStart:       time.UnixMilli(showtimeMetadata.Showtime.TimeStart).In(location),
+			TimeEnd:         time.UnixMilli(showtimeMetadata.Showtime.TimeEnd).In(location),
+		},
+	}, nil
+}
+
+// genTicketCards renders and uploads the ticket card of each ticket of params, returning their S3 filenames
+// by booking. There are no ticket cards if no PNG renderer is registered.
+func (n notificationLogic) genTicketCards(ctx context.Context, params pdfgenerator.PDFGenerateParams) map[uint32]string {
+	bookingIdToTicketCardFilename := make(map[uint32]string, len(params.Tickets))
+
+	ticketCardRenderer, ok := n.renderers.Get(pdfgenerator.FormatPNG)
+	if !ok {
+		return bookingIdToTicketCardFilename
+	}
+	for _, ticket := range params.Tickets {
+		logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, ticket.BookingId), n.logger)
+
+		ticketParams := params
+		ticketParams.BookingId = ticket.BookingId
+		ticketParams.Tickets = []pdfgenerator.PDFTicket{ticket}
+
+		renderCtx, renderSpan := n.tracer.Start(ctx, "render_ticket_card")
+		generateResult, err := ticketCardRenderer.Generate(renderCtx, ticketParams)
+		utils.EndSpan(renderSpan, err)
+		if err != nil {
+			logger.With(zap.Error(err)).Warn("failed to render ticket card")
+			continue
+		}
+
+		ticketCardFilename := fmt.Sprintf("ticket_card_%d%s", ticket.BookingId, ticketCardRenderer.Format().Extension())
+		if err := n.s3DM.UploadFile(ctx, ticketCardFilename, generateResult.Data); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to upload ticket card")
+			continue
+		}
+		bookingIdToTicketCardFilename[ticket.BookingId] = ticketCardFilename
+	}
+
+	return bookingIdToTicketCardFilename
+}
+
+// getMoviePoster returns the poster image of movie from the poster bucket of MovieService, or nil if it has
+// none or it cannot be read.
+func (n notificationLogic) getMoviePoster(ctx context.Context, movie *movie_service.Movie) []byte {
+	posterFilename := movie.GetPoster().GetOriginalImageFileName()
+	if posterFilename == "" {
+		return nil
+	}
+
+	poster, err := n.posterClient.GetFile(ctx, posterFilename)
 	if err != nil {
-		return "", err
+		utils.LoggerWithContext(ctx, n.logger).
+			With(zap.String("poster_filename", posterFilename)).
+			With(zap.Error(err)).
+			Warn("failed to get movie poster, ticket card will have none")
+		return nil
 	}
 
-	if err := n.s3DM.UploadFile(ctx, originalPDFFilename, fileData); err != nil {
-		return "", err
+	return poster
+}
+
+// newSeatMap returns the seat map of the screen of a showtime, with the seats of the bookings highlighted.
+func newSeatMap(showtimeMetadata *movie_service.ShowtimeMetadata, bookedSeatIdSet map[uint32]bool) *pdfgenerator.SeatMap {
+	if len(showtimeMetadata.GetSeats()) == 0 {
+		return nil
+	}
+
+	seatMap := &pdfgenerator.SeatMap{
+		ColumnCount: showtimeMetadata.GetScreen().GetScreenType().GetSeatOfRowCount(),
+		Seats:       make([]pdfgenerator.SeatMapSeat, 0, len(showtimeMetadata.GetSeats())),
+	}
+	for _, seat := range showtimeMetadata.GetSeats() {
+		seatMap.Seats = append(seatMap.Seats, pdfgenerator.SeatMapSeat{
+			Row:      seat.GetRow(),
+			Column:   seat.GetColumn(),
+			SeatType: seat.GetSeatType().GetDisplayName(),
+			Booked:   bookedSeatIdSet[seat.GetId()],
+		})
 	}
 
-	return originalPDFFilename, nil
+	return seatMap
 }
 
 func (n notificationLogic) getShowtimeMetadata(
 	ctx context.Context,
 	showtimeId uint32,
 ) (movie_service.ShowtimeMetadata, error) {
-	logger := n.logger.With(zap.Any("get showtime metadata", showtimeId))
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint32("showtime_id", showtimeId))
 
 	getShowtimeMetadataResp, getShowtimeMetadataErr := n.movieSerServiceClient.GetShowtimeMetadata(
 		ctx,
@@ -282,7 +1172,7 @@ func (n notificationLogic) getBooking(
 	ctx context.Context,
 	bookingId uint32,
 ) (booking_service.Booking, error) {
-	logger := n.logger.With(zap.Any("get booking", bookingId))
+	logger := utils.LoggerWithContext(utils.ContextWithBookingID(ctx, bookingId), n.logger)
 
 	getBookingResp, getBookingErr := n.bookingSerServiceClient.GetBookingById(
 		ctx,
@@ -300,7 +1190,7 @@ func (n notificationLogic) getSeat(
 	ctx context.Context,
 	seatId uint32,
 ) (movie_service.Seat, error) {
-	logger := n.logger.With(zap.Any("get seat", seatId))
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.Uint32("seat_id", seatId))
 
 	getSeatResp, getSeatErr := n.movieSerServiceClient.GetSeat(
 		ctx,
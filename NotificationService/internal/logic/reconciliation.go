@@ -0,0 +1,332 @@
+package logic
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/dataaccess/database"
+	"NotificationService/internal/generated/booking_service"
+	"NotificationService/internal/generated/user_service"
+	"NotificationService/internal/metrics"
+	"NotificationService/internal/utils"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultReconciliationPageSize = 100
+
+	// reconciliationAdvisoryLockKey is "NSRECONC" in ASCII.
+	reconciliationAdvisoryLockKey int64 = 0x4e535245434f4e43
+
+	InconsistencyReasonDuplicate           = "duplicate"
+	InconsistencyReasonFailed              = "failed"
+	InconsistencyReasonMissingPDF          = "missing_pdf"
+	InconsistencyReasonBookingNotConfirmed = "booking_not_confirmed"
+)
+
+type InconsistentNotification struct {
+	Notification database.Notification
+	Reason       string
+}
+
+type ReconciliationReport struct {
+	StartedAt             time.Time
+	FinishedAt            time.Time
+	DryRun                bool
+	ConfirmedBookingCount int
+	NotificationCount     int
+	// MissingBookingIdList are the confirmed bookings that had no notification. Unless the run is a dry run,
+	// a notification was created for each of them except the ones in CreateFailedBookingIdList.
+	MissingBookingIdList         []uint32
+	CreateFailedBookingIdList    []uint32
+	OrphanNotificationList       []database.Notification
+	InconsistentNotificationList []InconsistentNotification
+}
+
+type ReconciliationLogic interface {
+	// Reconcile diffs the confirmed bookings of BookingService against the notification table one page at a
+	// time, creates the missing notifications unless dryRun is set, and reports orphan and inconsistent
+	// notifications.
+	Reconcile(ctx context.Context, dryRun bool) (ReconciliationReport, error)
+	// TryReconcile runs Reconcile under a Postgres advisory lock, so that the replicas do not reconcile at the
+	// same time. It returns false without reconciling if another replica holds the lock.
+	TryReconcile(ctx context.Context) (bool, error)
+}
+
+type reconciliationLogic struct {
+	database                 database.Database
+	notificationLogic        NotificationLogic
+	notificationDataAccessor database.NotificationDataAccessor
+	userServiceClient        user_service.UserServiceClient
+	bookingServiceClient     booking_service.BookingServiceClient
+	pageSize                 uint32
+	logger                   *zap.Logger
+}
+
+func NewReconciliationLogic(
+	db database.Database,
+	notificationLogic NotificationLogic,
+	notificationDataAccessor database.NotificationDataAccessor,
+	userServiceClient user_service.UserServiceClient,
+	bookingServiceClient booking_service.BookingServiceClient,
+	reconciliationConfig configs.Reconciliation,
+	logger *zap.Logger,
+) ReconciliationLogic {
+	pageSize := reconciliationConfig.PageSize
+	if pageSize == 0 {
+		pageSize = defaultReconciliationPageSize
+	}
+
+	return &reconciliationLogic{
+		database:                 db,
+		notificationLogic:        notificationLogic,
+		notificationDataAccessor: notificationDataAccessor,
+		userServiceClient:        userServiceClient,
+		bookingServiceClient:     bookingServiceClient,
+		pageSize:                 pageSize,
+		logger:                   logger,
+	}
+}
+
+func (r reconciliationLogic) Reconcile(ctx context.Context, dryRun bool) (ReconciliationReport, error) {
+	logger := utils.LoggerWithContext(ctx, r.logger).With(zap.Bool("dry_run", dryRun))
+	report := ReconciliationReport{
+		StartedAt: time.Now(),
+		DryRun:    dryRun,
+	}
+
+	err := r.forEachConfirmedBookingIdPage(ctx, func(confirmedBookingIdList []uint32) error {
+		return r.reconcileConfirmedBookings(ctx, confirmedBookingIdList, dryRun, &report)
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to reconcile confirmed bookings")
+		metrics.ReconciliationRuns.WithLabelValues(metrics.ResultError).Inc()
+		return report, err
+	}
+
+	if err := r.reconcileNotifications(ctx, &report); err != nil {
+		logger.With(zap.Error(err)).Error("failed to reconcile notifications")
+		metrics.ReconciliationRuns.WithLabelValues(metrics.ResultError).Inc()
+		return report, err
+	}
+
+	report.FinishedAt = time.Now()
+	r.recordMetrics(report)
+
+	logger.
+		With(zap.Int("confirmed_booking_count", report.ConfirmedBookingCount)).
+		With(zap.Int("notification_count", report.NotificationCount)).
+		With(zap.Int("missing_count", len(report.MissingBookingIdList))).
+		With(zap.Int("orphan_count", len(report.OrphanNotificationList))).
+		With(zap.Int("inconsistent_count", len(report.InconsistentNotificationList))).
+		Info("reconciliation finished")
+
+	return report, nil
+}
+
+func (r reconciliationLogic) TryReconcile(ctx context.Context) (bool, error) {
+	return r.database.WithAdvisoryLock(ctx, reconciliationAdvisoryLockKey, func(ctx context.Context) error {
+		_, err := r.Reconcile(ctx, false)
+		return err
+	})
+}
+
+// reconcileConfirmedBookings creates the missing notifications of a page of confirmed bookings and reports
+// the notifications that do not match a confirmed booking.
+func (r reconciliationLogic) reconcileConfirmedBookings(
+	ctx context.Context,
+	confirmedBookingIdList []uint32,
+	dryRun bool,
+	report *ReconciliationReport,
+) error {
+	logger := utils.LoggerWithContext(ctx, r.logger)
+	report.ConfirmedBookingCount += len(confirmedBookingIdList)
+
+	notificationList, err := r.notificationDataAccessor.GetNotificationListByBookingIdList(ctx, confirmedBookingIdList)
+	if err != nil {
+		return err
+	}
+
+	// Duplicates are reported by reconcileNotifications, only the first notification of a booking is checked.
+	bookingIdToNotificationMap := make(map[uint32]*database.Notification, len(notificationList))
+	for _, notification := range notificationList {
+		if _, ok := bookingIdToNotificationMap[notification.OfBookingId]; !ok {
+			bookingIdToNotificationMap[notification.OfBookingId] = notification
+		}
+	}
+
+	for _, bookingId := range confirmedBookingIdList {
+		if notification, ok := bookingIdToNotificationMap[bookingId]; ok {
+			r.checkConfirmedBookingNotification(notification, report)
+			continue
+		}
+
+		report.MissingBookingIdList = append(report.MissingBookingIdList, bookingId)
+		if dryRun {
+			continue
+		}
+
+		err := r.notificationLogic.CreateNotification(ctx, bookingId)
+		if errors.Is(err, ErrNotificationAlreadyExists) {
+			// Created by its payment transaction completed event since the notifications were listed.
+			continue
+		}
+		if err != nil {
+			logger.With(zap.Uint32(utils.LogFieldBookingID, bookingId)).With(zap.Error(err)).Error("failed to create missing notification")
+			report.CreateFailedBookingIdList = append(report.CreateFailedBookingIdList, bookingId)
+		}
+	}
+
+	return nil
+}
+
+// checkConfirmedBookingNotification reports notification, whose booking is confirmed, if it was not sent.
+func (r reconciliationLogic) checkConfirmedBookingNotification(notification *database.Notification, report *ReconciliationReport) {
+	switch {
+	case notification.Status == database.NotificationStatus_NOTIFICATION_STATUS_FAILED:
+		report.InconsistentNotificationList = append(report.InconsistentNotificationList, InconsistentNotification{
+			Notification: *notification,
+			Reason:       InconsistencyReasonFailed,
+		})
+	case notification.Status == database.NotificationStatus_NOTIFICATION_STATUS_SUCCESS &&
+		notification.OriginalPDFFilename == "":
+		report.InconsistentNotificationList = append(report.InconsistentNotificationList, InconsistentNotification{
+			Notification: *notification,
+			Reason:       InconsistencyReasonMissingPDF,
+		})
+	}
+}
+
+// reconcileNotifications pages through the notifications, ordered by booking, and reports the duplicate ones
+// and the ones whose booking is unknown or not confirmed. The booking of each notification is fetched from
+// BookingService, since the confirmed bookings are not kept across pages.
+func (r reconciliationLogic) reconcileNotifications(ctx context.Context, report *ReconciliationReport) error {
+	previousBookingId := uint32(0)
+	for offset := uint32(0); ; offset += r.pageSize {
+		notificationList, err := r.notificationDataAccessor.GetNotificationList(ctx, offset, r.pageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, notification := range notificationList {
+			report.NotificationCount++
+
+			if notification.OfBookingId == previousBookingId {
+				report.InconsistentNotificationList = append(report.InconsistentNotificationList, InconsistentNotification{
+					Notification: *notification,
+					Reason:       InconsistencyReasonDuplicate,
+				})
+				continue
+			}
+			previousBookingId = notification.OfBookingId
+
+			if err := r.checkNotificationBooking(ctx, notification, report); err != nil {
+				return err
+			}
+		}
+
+		if uint32(len(notificationList)) < r.pageSize {
+			return nil
+		}
+	}
+}
+
+// checkNotificationBooking reports notification if it is orphan or its booking was never paid.
+func (r reconciliationLogic) checkNotificationBooking(
+	ctx context.Context,
+	notification *database.Notification,
+	report *ReconciliationReport,
+) error {
+	getBookingResp, err := r.bookingServiceClient.GetBookingById(
+		ctx,
+		&booking_service.GetBookingByIdRequest{BookingId: notification.OfBookingId},
+	)
+	if status.Code(err) == codes.NotFound {
+		report.OrphanNotificationList = append(report.OrphanNotificationList, *notification)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// A cancelled booking is expected, a confirmed one was checked with the confirmed bookings.
+	if getBookingResp.GetBooking().GetBookingStatus() == booking_service.BookingStatus_PENDING {
+		report.InconsistentNotificationList = append(report.InconsistentNotificationList, InconsistentNotification{
+			Notification: *notification,
+			Reason:       InconsistencyReasonBookingNotConfirmed,
+		})
+	}
+
+	return nil
+}
+
+// forEachConfirmedBookingIdPage pages through every user, as BookingService only lists bookings per user, and
+// calls fn with each page of their confirmed bookings.
+func (r reconciliationLogic) forEachConfirmedBookingIdPage(ctx context.Context, fn func(bookingIdList []uint32) error) error {
+	for userOffset := uint32(0); ; userOffset += r.pageSize {
+		getUserListResp, err := r.userServiceClient.GetUserList(ctx, &user_service.GetUserListRequest{
+			Offset: userOffset,
+			Limit:  r.pageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, user := range getUserListResp.GetUserList() {
+			for bookingOffset := uint32(0); ; bookingOffset += r.pageSize {
+				getBookingListResp, err := r.bookingServiceClient.GetBookingList(ctx, &booking_service.GetBookingListRequest{
+					UserId:        user.GetId(),
+					BookingStatus: booking_service.BookingStatus_CONFIRMED,
+					Offset:        bookingOffset,
+					Limit:         r.pageSize,
+				})
+				if err != nil {
+					return err
+				}
+
+				bookingIdList := make([]uint32, 0, len(getBookingListResp.GetBookingList()))
+				for _, bookingMetadata := range getBookingListResp.GetBookingList() {
+					bookingIdList = append(bookingIdList, bookingMetadata.GetBooking().GetId())
+				}
+				if len(bookingIdList) > 0 {
+					if err := fn(bookingIdList); err != nil {
+						return err
+					}
+				}
+
+				if uint32(len(getBookingListResp.GetBookingList())) < r.pageSize {
+					break
+				}
+			}
+		}
+
+		if uint32(len(getUserListResp.GetUserList())) < r.pageSize {
+			return nil
+		}
+	}
+}
+
+func (r reconciliationLogic) recordMetrics(report ReconciliationReport) {
+	metrics.ReconciliationMissingNotifications.Set(float64(len(report.MissingBookingIdList)))
+	metrics.ReconciliationOrphanNotifications.Set(float64(len(report.OrphanNotificationList)))
+
+	inconsistentCounts := map[string]int{
+		InconsistencyReasonDuplicate:           0,
+		InconsistencyReasonFailed:              0,
+		InconsistencyReasonMissingPDF:          0,
+		InconsistencyReasonBookingNotConfirmed: 0,
+	}
+	for _, inconsistentNotification := range report.InconsistentNotificationList {
+		inconsistentCounts[inconsistentNotification.Reason]++
+	}
+	for reason, count := range inconsistentCounts {
+		metrics.ReconciliationInconsistentNotifications.WithLabelValues(reason).Set(float64(count))
+	}
+
+	metrics.ReconciliationLastRunTimestamp.Set(float64(report.FinishedAt.Unix()))
+	metrics.ReconciliationRuns.WithLabelValues(metrics.ResultSuccess).Inc()
+}
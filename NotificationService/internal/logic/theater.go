@@ -0,0 +1,62 @@
+package logic
+
+import (
+	"NotificationService/internal/configs"
+	"fmt"
+	"time"
+
+	// Theaters may be in any time zone, and the container may not ship the time zone database.
+	_ "time/tzdata"
+)
+
+const (
+	defaultTheaterTimeZone = "Asia/Ho_Chi_Minh"
+)
+
+type TheaterLogic interface {
+	// GetLocation returns the time zone of a theater, every time rendered for one of its showtimes is in it.
+	GetLocation(theaterId uint32) *time.Location
+}
+
+type theaterLogic struct {
+	defaultLocation     *time.Location
+	theaterIdToLocation map[uint32]*time.Location
+}
+
+func NewTheaterLogic(theaterConfig configs.Theater) (TheaterLogic, error) {
+	defaultTimeZone := theaterConfig.DefaultTimeZone
+	if defaultTimeZone == "" {
+		defaultTimeZone = defaultTheaterTimeZone
+	}
+	defaultLocation, err := time.LoadLocation(defaultTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default theater time zone: %w", err)
+	}
+
+	theaterIdToLocation := make(map[uint32]*time.Location)
+	for _, theaterTimeZone := range theaterConfig.TimeZones {
+		location, err := time.LoadLocation(theaterTimeZone.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid theater time zone %s: %w", theaterTimeZone.TimeZone, err)
+		}
+
+		for _, theaterId := range theaterTimeZone.TheaterIds {
+			if _, ok := theaterIdToLocation[theaterId]; !ok {
+				theaterIdToLocation[theaterId] = location
+			}
+		}
+	}
+
+	return &theaterLogic{
+		defaultLocation:     defaultLocation,
+		theaterIdToLocation: theaterIdToLocation,
+	}, nil
+}
+
+func (t theaterLogic) GetLocation(theaterId uint32) *time.Location {
+	if location, ok := t.theaterIdToLocation[theaterId]; ok {
+		return location
+	}
+
+	return t.defaultLocation
+}
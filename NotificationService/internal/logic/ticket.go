@@ -0,0 +1,110 @@
+package logic
+
+import (
+	"NotificationService/internal/configs"
+	"NotificationService/internal/generated/booking_service"
+	"NotificationService/pkg/ticket"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTicketExpiryAfterShowtimeEnd = 6 * time.Hour
+)
+
+type TicketLogic interface {
+	// IssueTicketToken signs the token encoded in the QR code of the ticket of booking, which stays valid
+	// until a while after showtimeEnd.
+	IssueTicketToken(booking *booking_service.Booking, showtimeEnd time.Time) (string, error)
+	// VerifyTicketToken returns the claims of token if it was signed with one of the configured keys and is
+	// not expired.
+	VerifyTicketToken(token string) (ticket.Claims, error)
+}
+
+type ticketLogic struct {
+	signer                 *ticket.Signer
+	verifier               *ticket.Verifier
+	expiryAfterShowtimeEnd time.Duration
+	logger                 *zap.Logger
+}
+
+func NewTicketLogic(ticketConfig configs.Ticket, logger *zap.Logger) (TicketLogic, error) {
+	signer, keySet, err := newTicketSignerAndKeySet(ticketConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	expiryAfterShowtimeEnd := ticketConfig.ExpiryAfterShowtimeEnd
+	if expiryAfterShowtimeEnd <= 0 {
+		expiryAfterShowtimeEnd = defaultTicketExpiryAfterShowtimeEnd
+	}
+
+	return &ticketLogic{
+		signer:                 signer,
+		verifier:               ticket.NewVerifier(keySet),
+		expiryAfterShowtimeEnd: expiryAfterShowtimeEnd,
+		logger:                 logger,
+	}, nil
+}
+
+func newTicketSignerAndKeySet(ticketConfig configs.Ticket) (*ticket.Signer, ticket.KeySet, error) {
+	// Every replica must sign with the same key, a key of its own would make its tickets fail the check-in
+	// on the other replicas and after a restart.
+	if len(ticketConfig.SigningKeys) == 0 {
+		return nil, nil, errors.New(
+			"no ticket signing key is configured, generate one with the generate-ticket-signing-key command " +
+				"and add it to ticket.signing_keys with its id as ticket.signing_key_id",
+		)
+	}
+
+	var (
+		signer *ticket.Signer
+		keySet = make(ticket.KeySet)
+	)
+	for _, key := range ticketConfig.SigningKeys {
+		if key.PrivateKey == "" {
+			publicKey, err := ticket.ParsePublicKey(key.PublicKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid public key of ticket signing key %s: %w", key.ID, err)
+			}
+			keySet[key.ID] = publicKey
+			continue
+		}
+
+		privateKey, err := ticket.ParsePrivateKey(key.PrivateKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid private key of ticket signing key %s: %w", key.ID, err)
+		}
+		keySigner, err := ticket.NewSigner(key.ID, privateKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		keySet[key.ID] = keySigner.PublicKey()
+
+		if key.ID == ticketConfig.SigningKeyID {
+			signer = keySigner
+		}
+	}
+
+	if signer == nil {
+		return nil, nil, errors.New("ticket signing key id does not match any signing key with a private key")
+	}
+
+	return signer, keySet, nil
+}
+
+func (t ticketLogic) IssueTicketToken(booking *booking_service.Booking, showtimeEnd time.Time) (string, error) {
+	return t.signer.Sign(ticket.Claims{
+		BookingId:  booking.GetId(),
+		ShowtimeId: booking.GetOfShowtimeId(),
+		SeatId:     booking.GetOfSeatId(),
+		ExpiresAt:  showtimeEnd.Add(t.expiryAfterShowtimeEnd),
+	})
+}
+
+func (t ticketLogic) VerifyTicketToken(token string) (ticket.Claims, error) {
+	return t.verifier.Verify(token, time.Now())
+}
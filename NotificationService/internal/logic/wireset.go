@@ -5,4 +5,13 @@ import "github.com/google/wire"
 var WireSet = wire.NewSet(
 	NewMailer,
 	NewNotificationLogic,
+	NewReconciliationLogic,
+	NewBackfillLogic,
+	NewDeliveryAttemptLogic,
+	NewTicketLogic,
+	NewCheckInLogic,
+	NewBrandingLogic,
+	NewInvoiceLogic,
+	NewTheaterLogic,
+	NewHealthLogic,
 )
@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "notification_service"
+
+	NotificationTypeInvoice  = "invoice"
+	NotificationTypeBackfill = "backfill"
+
+	ChannelEmail = "email"
+
+	NotificationEventCreated = "created"
+	NotificationEventSent    = "sent"
+	NotificationEventFailed  = "failed"
+
+	ResultSuccess = "success"
+	ResultError   = "error"
+
+	CacheResultHit  = "hit"
+	CacheResultMiss = "miss"
+
+	CacheOperationGet = "get"
+	CacheOperationSet = "set"
+)
+
+var (
+	Notifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_total",
+		Help:      "Notifications created, sent and failed, by type and channel.",
+	}, []string{"type", "channel", "event"})
+
+	PDFGenerationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "pdf_generation_duration_seconds",
+		Help:      "Time spent generating invoice PDFs.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	S3UploadDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "s3_upload_duration_seconds",
+		Help:      "Time spent uploading files to S3.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	SMTPSendDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "smtp_send_duration_seconds",
+		Help:      "Time spent sending emails through SMTP.",
+		Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"result"})
+
+	DownstreamRPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "downstream_rpc_duration_seconds",
+		Help:      "Time spent on calls to other services, retries included, by service, method and code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "code"})
+
+	GRPCServerHandlingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_server_handling_seconds",
+		Help:      "Time spent handling gRPC requests, by method and code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	GRPCServerHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_server_handled_total",
+		Help:      "gRPC requests handled, by method and code.",
+	}, []string{"method", "code"})
+
+	KafkaConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kafka_consumer_lag",
+		Help:      "Messages between the last consumed message and the end of the partition.",
+	}, []string{"topic", "partition"})
+
+	CacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "lookups_total",
+		Help:      "Cache lookups, by cache namespace and result.",
+	}, []string{"namespace", "result"})
+
+	CacheLoads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "loads_total",
+		Help:      "Values loaded on cache misses, concurrent misses of a key sharing one load, by cache namespace and result.",
+	}, []string{"namespace", "result"})
+
+	CacheStoreErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "store_errors_total",
+		Help:      "Failed reads and writes of the cache store, by cache namespace and operation.",
+	}, []string{"namespace", "operation"})
+
+	ReconciliationMissingNotifications = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "reconciliation",
+		Name:      "missing_notifications",
+		Help:      "Confirmed bookings without notification found by the last reconciliation.",
+	})
+
+	ReconciliationOrphanNotifications = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "reconciliation",
+		Name:      "orphan_notifications",
+		Help:      "Notifications of bookings unknown to BookingService found by the last reconciliation.",
+	})
+
+	ReconciliationInconsistentNotifications = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "reconciliation",
+		Name:      "inconsistent_notifications",
+		Help:      "Notifications not matching the state of their booking found by the last reconciliation.",
+	}, []string{"reason"})
+
+	ReconciliationLastRunTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "reconciliation",
+		Name:      "last_run_timestamp_seconds",
+		Help:      "Time the last reconciliation finished.",
+	})
+
+	ReconciliationRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "reconciliation",
+		Name:      "runs_total",
+		Help:      "Reconciliation runs by result.",
+	}, []string{"result"})
+)
+
+func Result(err error) string {
+	if err != nil {
+		return ResultError
+	}
+
+	return ResultSuccess
+}
+
+// ObserveDuration records the time elapsed since start in histogram, labelled with the result of err.
+func ObserveDuration(histogram *prometheus.HistogramVec, start time.Time, err error) {
+	histogram.WithLabelValues(Result(err)).Observe(time.Since(start).Seconds())
+}
@@ -2,7 +2,6 @@ package utils
 
 import (
 	"NotificationService/internal/configs"
-	"context"
 
 	"go.uber.org/zap"
 )
@@ -39,7 +38,3 @@ func getZapLoggerLevel(level string) zap.AtomicLevel {
 		return zap.NewAtomicLevelAt(zap.InfoLevel)
 	}
 }
-
-func LoggerWithContext(_ context.Context, logger *zap.Logger) *zap.Logger {
-	return logger
-}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Names of the fields identifying what a log line is about. Every package logs these ids under these names,
+// so that the lines of one request or notification can be found with a single query.
+const (
+	LogFieldRequestID      = "request_id"
+	LogFieldTraceID        = "trace_id"
+	LogFieldSpanID         = "span_id"
+	LogFieldBookingID      = "booking_id"
+	LogFieldNotificationID = "notification_id"
+	LogFieldUserID         = "user_id"
+	LogFieldQueueName      = "queue_name"
+)
+
+type (
+	requestIDContextKey      struct{}
+	bookingIDContextKey      struct{}
+	notificationIDContextKey struct{}
+)
+
+// NewRequestID returns a random id for a request that came in without one.
+func NewRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(id)
+}
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+func ContextWithBookingID(ctx context.Context, bookingID uint32) context.Context {
+	return context.WithValue(ctx, bookingIDContextKey{}, bookingID)
+}
+
+func ContextWithNotificationID(ctx context.Context, notificationID uint32) context.Context {
+	return context.WithValue(ctx, notificationIDContextKey{}, notificationID)
+}
+
+// LoggerWithContext returns logger with the request, trace, booking and notification ids carried by ctx.
+func LoggerWithContext(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	fields := make([]zap.Field, 0, 5)
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		fields = append(fields, zap.String(LogFieldRequestID, requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = append(fields,
+			zap.String(LogFieldTraceID, spanContext.TraceID().String()),
+			zap.String(LogFieldSpanID, spanContext.SpanID().String()),
+		)
+	}
+	if bookingID, ok := ctx.Value(bookingIDContextKey{}).(uint32); ok {
+		fields = append(fields, zap.Uint32(LogFieldBookingID, bookingID))
+	}
+	if notificationID, ok := ctx.Value(notificationIDContextKey{}).(uint32); ok {
+		fields = append(fields, zap.Uint32(LogFieldNotificationID, notificationID))
+	}
+
+	return logger.With(fields...)
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKeyRequestID is the gRPC metadata key carrying the request id between services.
+const MetadataKeyRequestID = "x-request-id"
+
+// contextWithIncomingRequestID carries the request id of the incoming gRPC metadata of ctx, or a new one if
+// the caller sent none, and returns it to the caller in the response header.
+func contextWithIncomingRequestID(ctx context.Context) context.Context {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKeyRequestID); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = NewRequestID()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataKeyRequestID, requestID))
+	return ContextWithRequestID(ctx, requestID)
+}
+
+func RequestIDUnaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	return handler(contextWithIncomingRequestID(ctx), req)
+}
+
+func RequestIDStreamServerInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &requestIDServerStream{
+		ServerStream: ss,
+		ctx:          contextWithIncomingRequestID(ss.Context()),
+	})
+}
+
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// RequestIDUnaryClientInterceptor passes the request id of ctx on to the called service.
+func RequestIDUnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, MetadataKeyRequestID, requestID)
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -1,12 +1,12 @@
 package utils
 
 import (
+	"context"
 	"os"
 	"os/signal"
 )
 
-func WaitForSignals(signals ...os.Signal) {
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, signals...)
-	<-ch
+// ContextWithSignals returns a copy of ctx that is cancelled when one of the signals is received.
+func ContextWithSignals(ctx context.Context, signals ...os.Signal) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, signals...)
 }
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"NotificationService/internal/configs"
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+
+	defaultTracingServiceName = "notification_service"
+	defaultTracingSampleRatio = 1
+	tracerShutdownTimeout     = 5 * time.Second
+)
+
+// NewTracerProvider returns the provider of the tracers instrumenting the service and installs the W3C trace
+// context propagator used for gRPC metadata and Kafka message headers.
+func NewTracerProvider(tracingConfig configs.Tracing, logger *zap.Logger) (trace.TracerProvider, func(), error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch tracingConfig.Exporter {
+	case "":
+		// Spans are not recorded, but the incoming trace context still reaches the outgoing calls.
+		return noop.NewTracerProvider(), func() {}, nil
+	case TracingExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(tracingConfig.OTLPEndpoint)}
+		if tracingConfig.OTLPInsecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), options...)
+	case TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, nil, fmt.Errorf("unsupported tracing exporter: %s", tracingConfig.Exporter)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s span exporter: %w", tracingConfig.Exporter, err)
+	}
+
+	serviceName := tracingConfig.ServiceName
+	if serviceName == "" {
+		serviceName = defaultTracingServiceName
+	}
+
+	sampleRatio := tracingConfig.SampleRatio
+	if sampleRatio <= 0 {
+		sampleRatio = defaultTracingSampleRatio
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+		defer cancel()
+
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			logger.With(zap.Error(err)).Error("failed to flush spans")
+		}
+	}
+
+	return tracerProvider, cleanup, nil
+}
+
+// EndSpan marks span as failed if err is not nil, then ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
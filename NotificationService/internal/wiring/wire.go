@@ -29,3 +29,21 @@ func InitStandaloneServer(configFilePath configs.ConfigFilePath) (app.Standalone
 
 	return app.StandaloneServer{}, nil, nil
 }
+
+func InitReplayer(configFilePath configs.ConfigFilePath) (app.Replayer, func(), error) {
+	wire.Build(WireSet)
+
+	return app.Replayer{}, nil, nil
+}
+
+func InitReconciler(configFilePath configs.ConfigFilePath) (app.Reconciler, func(), error) {
+	wire.Build(WireSet)
+
+	return app.Reconciler{}, nil, nil
+}
+
+func InitBackfillLogic(configFilePath configs.ConfigFilePath) (logic.BackfillLogic, func(), error) {
+	wire.Build(WireSet)
+
+	return nil, nil, nil
+}
@@ -10,6 +10,7 @@ import (
 	"NotificationService/internal/app"
 	"NotificationService/internal/configs"
 	"NotificationService/internal/dataaccess"
+	"NotificationService/internal/dataaccess/cache"
 	"NotificationService/internal/dataaccess/database"
 	"NotificationService/internal/dataaccess/kafka/consumer"
 	"NotificationService/internal/dataaccess/kafka/producer"
@@ -17,9 +18,12 @@ import (
 	"NotificationService/internal/handler"
 	"NotificationService/internal/handler/consumers"
 	"NotificationService/internal/handler/grpc"
+	"NotificationService/internal/handler/grpc/clients"
 	userservice3 "NotificationService/internal/handler/grpc/clients/booking_service"
 	userservice2 "NotificationService/internal/handler/grpc/clients/movie_service"
 	"NotificationService/internal/handler/grpc/clients/user_service"
+	"NotificationService/internal/handler/http"
+	"NotificationService/internal/handler/jobs"
 	"NotificationService/internal/handler/pdf_generator"
 	"NotificationService/internal/logic"
 	"NotificationService/internal/utils"
@@ -46,81 +50,672 @@ func InitStandaloneServer(configFilePath configs.ConfigFilePath) (app.Standalone
 		return app.StandaloneServer{}, nil, err
 	}
 	notificationDataAccessor := database.NewNotificationDataAccessor(databaseDatabase, logger)
-	pdfGenerator := pdfgenerator.NewPDFGenerator(logger)
+	configsPDFGenerator := config.PDFGenerator
+	renderers, err := pdfgenerator.NewRenderers(configsPDFGenerator, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
+	ticket := config.Ticket
+	ticketLogic, err := logic.NewTicketLogic(ticket, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
 	mail := config.Mail
+	tracing := config.Tracing
+	tracerProvider, cleanup3, err := utils.NewTracerProvider(tracing, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
 	configsS3 := config.S3
-	client, err := s3.NewClient(configsS3, logger)
+	client, err := s3.NewClient(configsS3, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
+	posterClient, err := s3.NewPosterClient(configsS3, tracerProvider, logger)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
-	mailer := logic.NewMailer(mail, logger, client)
+	deliveryAttemptDataAccessor := database.NewDeliveryAttemptDataAccessor(databaseDatabase, logger)
+	mailer := logic.NewMailer(mail, tracerProvider, logger, client, deliveryAttemptDataAccessor)
 	kafka := config.Kafka
-	producerProducer, err := producer.NewProducer(kafka, logger)
+	producerProducer, err := producer.NewProducer(kafka, tracerProvider, logger)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
-	notificationCreatedProducer := producer.NewNotificationCreatedProducer(producerProducer, logger)
-	db, cleanup3, err := database.NewGORMDatabase(configsDatabase)
+	notificationCreatedProducer := producer.NewNotificationCreatedProducer(producerProducer, kafka, logger)
+	db, cleanup4, err := database.NewGORMDatabase(configsDatabase)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
 	userServiceClient := config.UserServiceClient
-	user_serviceUserServiceClient, err := userservice.NewClient(userServiceClient, logger)
+	configsCache := config.Cache
+	cacheClient, cleanup5, err := cache.NewClient(configsCache, logger)
 	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
+	user_serviceUserServiceClient, err := userservice.NewClient(userServiceClient, cacheClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
 	movieServiceClient := config.MovieServiceClient
-	movie_serviceMovieServiceClient, err := userservice2.NewClient(movieServiceClient, logger)
+	movie_serviceMovieServiceClient, err := userservice2.NewClient(movieServiceClient, cacheClient, tracerProvider, logger)
 	if err != nil {
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
 	bookingServiceClient := config.BookingServiceClient
-	booking_serviceBookingServiceClient, err := userservice3.NewClient(bookingServiceClient, logger)
+	booking_serviceBookingServiceClient, err := userservice3.NewClient(bookingServiceClient, tracerProvider, logger)
 	if err != nil {
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
-	notificationLogic := logic.NewNotificationLogic(notificationDataAccessor, pdfGenerator, mailer, client, notificationCreatedProducer, logger, db, user_serviceUserServiceClient, movie_serviceMovieServiceClient, booking_serviceBookingServiceClient)
-	notificationServiceServer, err := grpc.NewHandler(notificationLogic)
+	branding := config.Branding
+	brandingLogic, err := logic.NewBrandingLogic(branding, client, cacheClient, logger)
 	if err != nil {
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
-	server := grpc.NewServer(configsGRPC, notificationServiceServer)
-	notificationCreatedMessageHandler := consumers.NewNotificationCreatedMessageHandler(notificationLogic, logger)
-	paymentTransactionCompletedMessageHandler := consumers.NewPaymentTransactionCompletedMessageHandler(notificationLogic, logger)
-	consumerConsumer, err := consumer.NewConsumer(kafka, logger)
+	invoiceDataAccessor := database.NewInvoiceDataAccessor(databaseDatabase, logger)
+	invoice := config.Invoice
+	invoiceLogic, err := logic.NewInvoiceLogic(invoiceDataAccessor, invoice, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
+	theater := config.Theater
+	theaterLogic, err := logic.NewTheaterLogic(theater)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
+	notificationGroupDataAccessor := database.NewNotificationGroupDataAccessor(databaseDatabase, logger)
+	notificationGroup := config.NotificationGroup
+	notificationScheduler := config.NotificationScheduler
+	notificationLogic := logic.NewNotificationLogic(notificationDataAccessor, renderers, ticketLogic, mailer, client, notificationCreatedProducer, logger, db, user_serviceUserServiceClient, movie_serviceMovieServiceClient, booking_serviceBookingServiceClient, tracerProvider, brandingLogic, invoiceLogic, theaterLogic, notificationGroupDataAccessor, notificationGroup, notificationScheduler, posterClient)
+	deliveryAttemptLogic := logic.NewDeliveryAttemptLogic(deliveryAttemptDataAccessor)
+	ticketCheckInDataAccessor := database.NewTicketCheckInDataAccessor(databaseDatabase, logger)
+	checkIn := config.CheckIn
+	checkInLogic := logic.NewCheckInLogic(ticketLogic, ticketCheckInDataAccessor, booking_serviceBookingServiceClient, movie_serviceMovieServiceClient, checkIn, logger)
+	notificationServiceServer, err := grpc.NewHandler(notificationLogic, deliveryAttemptLogic, checkInLogic)
 	if err != nil {
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
-	notificationServiceKafkaConsumer := consumers.NewNotificationServiceKafkaConsumer(notificationCreatedMessageHandler, paymentTransactionCompletedMessageHandler, consumerConsumer, logger)
-	standaloneServer, err := app.NewStandAloneServer(server, notificationServiceKafkaConsumer, logger)
+	health := config.Health
+	consumerConsumer, err := consumer.NewConsumer(kafka, tracerProvider, logger)
 	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
+	healthChecker, cleanup6, err := clients.NewHealthChecker(userServiceClient, movieServiceClient, bookingServiceClient, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.StandaloneServer{}, nil, err
+	}
+	healthLogic := logic.NewHealthLogic(health, databaseDatabase, consumerConsumer, client, mailer, healthChecker, logger)
+	staffAuthenticator := grpc.NewStaffAuthenticator(configsGRPC, checkIn, user_serviceUserServiceClient, logger)
+	server := grpc.NewServer(configsGRPC, notificationServiceServer, healthLogic, tracerProvider, staffAuthenticator)
+	configsHTTP := config.HTTP
+	httpServer := http.NewServer(configsHTTP, healthLogic)
+	notificationCreatedMessageHandler := consumers.NewNotificationCreatedMessageHandler(notificationLogic, logger)
+	paymentTransactionCompletedMessageHandler := consumers.NewPaymentTransactionCompletedMessageHandler(notificationLogic, logger)
+	eventQuarantineProducer := producer.NewEventQuarantineProducer(producerProducer, logger)
+	eventDeadLetterProducer := producer.NewEventDeadLetterProducer(producerProducer, logger)
+	notificationServiceKafkaConsumer := consumers.NewNotificationServiceKafkaConsumer(notificationCreatedMessageHandler, paymentTransactionCompletedMessageHandler, eventQuarantineProducer, eventDeadLetterProducer, consumerConsumer, logger)
+	reconciliation := config.Reconciliation
+	reconciliationLogic := logic.NewReconciliationLogic(databaseDatabase, notificationLogic, notificationDataAccessor, user_serviceUserServiceClient, booking_serviceBookingServiceClient, reconciliation, logger)
+	reconciliationJob := jobs.NewReconciliationJob(reconciliationLogic, reconciliation, logger)
+	notificationSchedulerJob := jobs.NewNotificationSchedulerJob(notificationLogic, notificationScheduler, logger)
+	lifecycle := config.Lifecycle
+	appLifecycle := app.NewLifecycle(lifecycle, logger)
+	standaloneServer, err := app.NewStandAloneServer(server, httpServer, notificationServiceKafkaConsumer, producerProducer, reconciliationJob, notificationSchedulerJob, appLifecycle, logger)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return app.StandaloneServer{}, nil, err
 	}
 	return standaloneServer, func() {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitReplayer(configFilePath configs.ConfigFilePath) (app.Replayer, func(), error) {
+	config, err := configs.NewConfig(configFilePath)
+	if err != nil {
+		return app.Replayer{}, nil, err
+	}
+	configsDatabase := config.Database
+	databaseDatabase, cleanup, err := database.NewDatabase(configsDatabase)
+	if err != nil {
+		return app.Replayer{}, nil, err
+	}
+	log := config.Log
+	logger, cleanup2, err := utils.NewLogger(log)
+	if err != nil {
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	notificationDataAccessor := database.NewNotificationDataAccessor(databaseDatabase, logger)
+	configsPDFGenerator := config.PDFGenerator
+	renderers, err := pdfgenerator.NewRenderers(configsPDFGenerator, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	ticket := config.Ticket
+	ticketLogic, err := logic.NewTicketLogic(ticket, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	mail := config.Mail
+	tracing := config.Tracing
+	tracerProvider, cleanup3, err := utils.NewTracerProvider(tracing, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	configsS3 := config.S3
+	client, err := s3.NewClient(configsS3, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	posterClient, err := s3.NewPosterClient(configsS3, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	deliveryAttemptDataAccessor := database.NewDeliveryAttemptDataAccessor(databaseDatabase, logger)
+	mailer := logic.NewMailer(mail, tracerProvider, logger, client, deliveryAttemptDataAccessor)
+	kafka := config.Kafka
+	producerProducer, err := producer.NewProducer(kafka, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	notificationCreatedProducer := producer.NewNotificationCreatedProducer(producerProducer, kafka, logger)
+	db, cleanup4, err := database.NewGORMDatabase(configsDatabase)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	userServiceClient := config.UserServiceClient
+	configsCache := config.Cache
+	cacheClient, cleanup5, err := cache.NewClient(configsCache, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	user_serviceUserServiceClient, err := userservice.NewClient(userServiceClient, cacheClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	movieServiceClient := config.MovieServiceClient
+	movie_serviceMovieServiceClient, err := userservice2.NewClient(movieServiceClient, cacheClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	bookingServiceClient := config.BookingServiceClient
+	booking_serviceBookingServiceClient, err := userservice3.NewClient(bookingServiceClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	branding := config.Branding
+	brandingLogic, err := logic.NewBrandingLogic(branding, client, cacheClient, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	invoiceDataAccessor := database.NewInvoiceDataAccessor(databaseDatabase, logger)
+	invoice := config.Invoice
+	invoiceLogic, err := logic.NewInvoiceLogic(invoiceDataAccessor, invoice, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	theater := config.Theater
+	theaterLogic, err := logic.NewTheaterLogic(theater)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Replayer{}, nil, err
+	}
+	notificationGroupDataAccessor := database.NewNotificationGroupDataAccessor(databaseDatabase, logger)
+	notificationGroup := config.NotificationGroup
+	notificationScheduler := config.NotificationScheduler
+	notificationLogic := logic.NewNotificationLogic(notificationDataAccessor, renderers, ticketLogic, mailer, client, notificationCreatedProducer, logger, db, user_serviceUserServiceClient, movie_serviceMovieServiceClient, booking_serviceBookingServiceClient, tracerProvider, brandingLogic, invoiceLogic, theaterLogic, notificationGroupDataAccessor, notificationGroup, notificationScheduler, posterClient)
+	notificationCreatedMessageHandler := consumers.NewNotificationCreatedMessageHandler(notificationLogic, logger)
+	paymentTransactionCompletedMessageHandler := consumers.NewPaymentTransactionCompletedMessageHandler(notificationLogic, logger)
+	replayer := consumer.NewReplayer(kafka, tracerProvider, logger)
+	notificationServiceEventReplayer := consumers.NewNotificationServiceEventReplayer(notificationCreatedMessageHandler, paymentTransactionCompletedMessageHandler, notificationLogic, replayer, logger)
+	appReplayer := app.NewReplayer(notificationServiceEventReplayer, producerProducer, logger)
+	return appReplayer, func() {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitReconciler(configFilePath configs.ConfigFilePath) (app.Reconciler, func(), error) {
+	config, err := configs.NewConfig(configFilePath)
+	if err != nil {
+		return app.Reconciler{}, nil, err
+	}
+	configsDatabase := config.Database
+	databaseDatabase, cleanup, err := database.NewDatabase(configsDatabase)
+	if err != nil {
+		return app.Reconciler{}, nil, err
+	}
+	log := config.Log
+	logger, cleanup2, err := utils.NewLogger(log)
+	if err != nil {
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	notificationDataAccessor := database.NewNotificationDataAccessor(databaseDatabase, logger)
+	configsPDFGenerator := config.PDFGenerator
+	renderers, err := pdfgenerator.NewRenderers(configsPDFGenerator, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	ticket := config.Ticket
+	ticketLogic, err := logic.NewTicketLogic(ticket, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	mail := config.Mail
+	tracing := config.Tracing
+	tracerProvider, cleanup3, err := utils.NewTracerProvider(tracing, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	configsS3 := config.S3
+	client, err := s3.NewClient(configsS3, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	posterClient, err := s3.NewPosterClient(configsS3, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	deliveryAttemptDataAccessor := database.NewDeliveryAttemptDataAccessor(databaseDatabase, logger)
+	mailer := logic.NewMailer(mail, tracerProvider, logger, client, deliveryAttemptDataAccessor)
+	kafka := config.Kafka
+	producerProducer, err := producer.NewProducer(kafka, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	notificationCreatedProducer := producer.NewNotificationCreatedProducer(producerProducer, kafka, logger)
+	db, cleanup4, err := database.NewGORMDatabase(configsDatabase)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	userServiceClient := config.UserServiceClient
+	configsCache := config.Cache
+	cacheClient, cleanup5, err := cache.NewClient(configsCache, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	user_serviceUserServiceClient, err := userservice.NewClient(userServiceClient, cacheClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	movieServiceClient := config.MovieServiceClient
+	movie_serviceMovieServiceClient, err := userservice2.NewClient(movieServiceClient, cacheClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	bookingServiceClient := config.BookingServiceClient
+	booking_serviceBookingServiceClient, err := userservice3.NewClient(bookingServiceClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	branding := config.Branding
+	brandingLogic, err := logic.NewBrandingLogic(branding, client, cacheClient, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	invoiceDataAccessor := database.NewInvoiceDataAccessor(databaseDatabase, logger)
+	invoice := config.Invoice
+	invoiceLogic, err := logic.NewInvoiceLogic(invoiceDataAccessor, invoice, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	theater := config.Theater
+	theaterLogic, err := logic.NewTheaterLogic(theater)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return app.Reconciler{}, nil, err
+	}
+	notificationGroupDataAccessor := database.NewNotificationGroupDataAccessor(databaseDatabase, logger)
+	notificationGroup := config.NotificationGroup
+	notificationScheduler := config.NotificationScheduler
+	notificationLogic := logic.NewNotificationLogic(notificationDataAccessor, renderers, ticketLogic, mailer, client, notificationCreatedProducer, logger, db, user_serviceUserServiceClient, movie_serviceMovieServiceClient, booking_serviceBookingServiceClient, tracerProvider, brandingLogic, invoiceLogic, theaterLogic, notificationGroupDataAccessor, notificationGroup, notificationScheduler, posterClient)
+	reconciliation := config.Reconciliation
+	reconciliationLogic := logic.NewReconciliationLogic(databaseDatabase, notificationLogic, notificationDataAccessor, user_serviceUserServiceClient, booking_serviceBookingServiceClient, reconciliation, logger)
+	reconciler := app.NewReconciler(reconciliationLogic, producerProducer, logger)
+	return reconciler, func() {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitBackfillLogic(configFilePath configs.ConfigFilePath) (logic.BackfillLogic, func(), error) {
+	config, err := configs.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	configsDatabase := config.Database
+	databaseDatabase, cleanup, err := database.NewDatabase(configsDatabase)
+	if err != nil {
+		return nil, nil, err
+	}
+	log := config.Log
+	logger, cleanup2, err := utils.NewLogger(log)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	notificationDataAccessor := database.NewNotificationDataAccessor(databaseDatabase, logger)
+	configsPDFGenerator := config.PDFGenerator
+	renderers, err := pdfgenerator.NewRenderers(configsPDFGenerator, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	ticket := config.Ticket
+	ticketLogic, err := logic.NewTicketLogic(ticket, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mail := config.Mail
+	tracing := config.Tracing
+	tracerProvider, cleanup3, err := utils.NewTracerProvider(tracing, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	configsS3 := config.S3
+	client, err := s3.NewClient(configsS3, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	posterClient, err := s3.NewPosterClient(configsS3, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	deliveryAttemptDataAccessor := database.NewDeliveryAttemptDataAccessor(databaseDatabase, logger)
+	mailer := logic.NewMailer(mail, tracerProvider, logger, client, deliveryAttemptDataAccessor)
+	kafka := config.Kafka
+	producerProducer, err := producer.NewProducer(kafka, tracerProvider, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	notificationCreatedProducer := producer.NewNotificationCreatedProducer(producerProducer, kafka, logger)
+	db, cleanup4, err := database.NewGORMDatabase(configsDatabase)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	userServiceClient := config.UserServiceClient
+	configsCache := config.Cache
+	cacheClient, cleanup5, err := cache.NewClient(configsCache, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	user_serviceUserServiceClient, err := userservice.NewClient(userServiceClient, cacheClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	movieServiceClient := config.MovieServiceClient
+	movie_serviceMovieServiceClient, err := userservice2.NewClient(movieServiceClient, cacheClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	bookingServiceClient := config.BookingServiceClient
+	booking_serviceBookingServiceClient, err := userservice3.NewClient(bookingServiceClient, tracerProvider, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	branding := config.Branding
+	brandingLogic, err := logic.NewBrandingLogic(branding, client, cacheClient, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	invoiceDataAccessor := database.NewInvoiceDataAccessor(databaseDatabase, logger)
+	invoice := config.Invoice
+	invoiceLogic, err := logic.NewInvoiceLogic(invoiceDataAccessor, invoice, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	theater := config.Theater
+	theaterLogic, err := logic.NewTheaterLogic(theater)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	notificationGroupDataAccessor := database.NewNotificationGroupDataAccessor(databaseDatabase, logger)
+	notificationGroup := config.NotificationGroup
+	notificationScheduler := config.NotificationScheduler
+	notificationLogic := logic.NewNotificationLogic(notificationDataAccessor, renderers, ticketLogic, mailer, client, notificationCreatedProducer, logger, db, user_serviceUserServiceClient, movie_serviceMovieServiceClient, booking_serviceBookingServiceClient, tracerProvider, brandingLogic, invoiceLogic, theaterLogic, notificationGroupDataAccessor, notificationGroup, notificationScheduler, posterClient)
+	backfillCheckpointDataAccessor := database.NewBackfillCheckpointDataAccessor(databaseDatabase, logger)
+	backfillLogic := logic.NewBackfillLogic(notificationLogic, backfillCheckpointDataAccessor, user_serviceUserServiceClient, booking_serviceBookingServiceClient, logger)
+	return backfillLogic, func() {
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
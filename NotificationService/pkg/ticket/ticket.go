@@ -0,0 +1,213 @@
+// Package ticket signs and verifies the tokens encoded in the QR code of a ticket.
+//
+// A token is a compact JWS (RFC 7515) signed with Ed25519, so that it can also be verified with any JWT
+// library supporting the EdDSA algorithm. The header carries the id of the signing key, which lets keys be
+// rotated: tickets signed with a retired key stay valid for as long as its public key is kept in the KeySet
+// of the verifiers.
+package ticket
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm = "EdDSA"
+	tokenType = "JWT"
+)
+
+var (
+	ErrMalformedToken   = errors.New("malformed ticket token")
+	ErrUnknownKey       = errors.New("ticket token is signed with an unknown key")
+	ErrInvalidSignature = errors.New("invalid ticket token signature")
+	ErrTokenExpired     = errors.New("ticket token is expired")
+)
+
+// Claims are the facts about a ticket attested by a token.
+type Claims struct {
+	BookingId  uint32
+	ShowtimeId uint32
+	SeatId     uint32
+	ExpiresAt  time.Time
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// payload keeps the claim names short, as the size of the token drives the density of the QR code.
+type payload struct {
+	BookingId  uint32 `json:"bid"`
+	ShowtimeId uint32 `json:"sid"`
+	SeatId     uint32 `json:"seat"`
+	ExpiresAt  int64  `json:"exp"`
+}
+
+type Signer struct {
+	keyID      string
+	privateKey ed25519.PrivateKey
+}
+
+func NewSigner(keyID string, privateKey ed25519.PrivateKey) (*Signer, error) {
+	if keyID == "" {
+		return nil, errors.New("ticket signing key id cannot be empty")
+	}
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid ticket signing key %s: expected %d bytes", keyID, ed25519.PrivateKeySize)
+	}
+
+	return &Signer{
+		keyID:      keyID,
+		privateKey: privateKey,
+	}, nil
+}
+
+func (s Signer) KeyID() string {
+	return s.keyID
+}
+
+func (s Signer) PublicKey() ed25519.PublicKey {
+	return s.privateKey.Public().(ed25519.PublicKey)
+}
+
+func (s Signer) Sign(claims Claims) (string, error) {
+	headerJSON, err := json.Marshal(header{Algorithm: algorithm, Type: tokenType, KeyID: s.keyID})
+	if err != nil {
+		return "", err
+	}
+
+	payloadJSON, err := json.Marshal(payload{
+		BookingId:  claims.BookingId,
+		ShowtimeId: claims.ShowtimeId,
+		SeatId:     claims.SeatId,
+		ExpiresAt:  claims.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(headerJSON) + "." + encodeSegment(payloadJSON)
+	signature := ed25519.Sign(s.privateKey, []byte(signingInput))
+
+	return signingInput + "." + encodeSegment(signature), nil
+}
+
+// KeySet maps key ids to the public keys accepted by a Verifier.
+type KeySet map[string]ed25519.PublicKey
+
+type Verifier struct {
+	keySet KeySet
+}
+
+func NewVerifier(keySet KeySet) *Verifier {
+	return &Verifier{
+		keySet: keySet,
+	}
+}
+
+// Verify checks the signature and expiry of token at time now, and returns its claims.
+func (v Verifier) Verify(token string, now time.Time) (Claims, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return Claims{}, ErrMalformedToken
+	}
+
+	var tokenHeader header
+	if err := decodeJSONSegment(segments[0], &tokenHeader); err != nil {
+		return Claims{}, err
+	}
+	if tokenHeader.Algorithm != algorithm {
+		return Claims{}, fmt.Errorf("%w: unsupported algorithm %q", ErrMalformedToken, tokenHeader.Algorithm)
+	}
+
+	publicKey, ok := v.keySet[tokenHeader.KeyID]
+	if !ok {
+		return Claims{}, fmt.Errorf("%w: %q", ErrUnknownKey, tokenHeader.KeyID)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %w", ErrMalformedToken, err)
+	}
+	if !ed25519.Verify(publicKey, []byte(segments[0]+"."+segments[1]), signature) {
+		return Claims{}, ErrInvalidSignature
+	}
+
+	var tokenPayload payload
+	if err := decodeJSONSegment(segments[1], &tokenPayload); err != nil {
+		return Claims{}, err
+	}
+
+	claims := Claims{
+		BookingId:  tokenPayload.BookingId,
+		ShowtimeId: tokenPayload.ShowtimeId,
+		SeatId:     tokenPayload.SeatId,
+		ExpiresAt:  time.Unix(tokenPayload.ExpiresAt, 0),
+	}
+	if !now.Before(claims.ExpiresAt) {
+		return claims, ErrTokenExpired
+	}
+
+	return claims, nil
+}
+
+// GenerateKey returns a new private key, together with its public key, encoded as expected by
+// ParsePrivateKey and ParsePublicKey.
+func GenerateKey() (privateKey string, publicKey string, err error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(private.Seed()), base64.StdEncoding.EncodeToString(public), nil
+}
+
+// ParsePrivateKey decodes the base64 encoded 32 bytes seed of an Ed25519 private key.
+func ParsePrivateKey(encoded string) (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid private key: expected %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ParsePublicKey decodes a base64 encoded Ed25519 public key.
+func ParsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key: expected %d bytes, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+
+	return publicKey, nil
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeJSONSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformedToken, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformedToken, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package ticket
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSigner(t *testing.T, keyID string, seedByte byte) *Signer {
+	t.Helper()
+
+	signer, err := NewSigner(keyID, ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seedByte}, ed25519.SeedSize)))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+
+	return signer
+}
+
+func mustEncodeJSONSegment(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	return encodeSegment(data)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Unix(1_790_000_000, 0)
+	claims := Claims{
+		BookingId:  42,
+		ShowtimeId: 7,
+		SeatId:     13,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+
+	currentSigner := newTestSigner(t, "2026-10", 1)
+	retiredSigner := newTestSigner(t, "2026-01", 2)
+	unknownSigner := newTestSigner(t, "unknown", 3)
+	impostorSigner := newTestSigner(t, "2026-10", 4)
+
+	verifier := NewVerifier(KeySet{
+		currentSigner.KeyID(): currentSigner.PublicKey(),
+		retiredSigner.KeyID(): retiredSigner.PublicKey(),
+	})
+
+	sign := func(signer *Signer, claims Claims) string {
+		token, err := signer.Sign(claims)
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+		return token
+	}
+	validToken := sign(currentSigner, claims)
+	validSegments := strings.Split(validToken, ".")
+
+	testCases := []struct {
+		name       string
+		token      string
+		now        time.Time
+		wantErr    error
+		wantClaims Claims
+	}{
+		{
+			name:       "valid token",
+			token:      validToken,
+			now:        now,
+			wantClaims: claims,
+		},
+		{
+			name:       "token signed with a retired key",
+			token:      sign(retiredSigner, claims),
+			now:        now,
+			wantClaims: claims,
+		},
+		{
+			name:       "expired token",
+			token:      validToken,
+			now:        claims.ExpiresAt,
+			wantErr:    ErrTokenExpired,
+			wantClaims: claims,
+		},
+		{
+			name:    "token signed with an unknown key",
+			token:   sign(unknownSigner, claims),
+			now:     now,
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "token signed with another key of the same id",
+			token:   sign(impostorSigner, claims),
+			now:     now,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "tampered payload",
+			token: strings.Join([]string{
+				validSegments[0],
+				mustEncodeJSONSegment(t, payload{BookingId: 43, ShowtimeId: 7, SeatId: 13, ExpiresAt: claims.ExpiresAt.Unix()}),
+				validSegments[2],
+			}, "."),
+			now:     now,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "unsupported algorithm",
+			token: strings.Join([]string{
+				mustEncodeJSONSegment(t, header{Algorithm: "none", Type: tokenType, KeyID: currentSigner.KeyID()}),
+				validSegments[1],
+				"",
+			}, "."),
+			now:     now,
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "missing signature",
+			token:   validSegments[0] + "." + validSegments[1],
+			now:     now,
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "header is not base64",
+			token:   "!!!." + validSegments[1] + "." + validSegments[2],
+			now:     now,
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "signature is not base64",
+			token:   validSegments[0] + "." + validSegments[1] + ".!!!",
+			now:     now,
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "empty token",
+			token:   "",
+			now:     now,
+			wantErr: ErrMalformedToken,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			gotClaims, err := verifier.Verify(testCase.token, testCase.now)
+			if !errors.Is(err, testCase.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, testCase.wantErr)
+			}
+			if testCase.wantErr != nil && testCase.wantErr != ErrTokenExpired {
+				return
+			}
+			if gotClaims.BookingId != testCase.wantClaims.BookingId ||
+				gotClaims.ShowtimeId != testCase.wantClaims.ShowtimeId ||
+				gotClaims.SeatId != testCase.wantClaims.SeatId ||
+				!gotClaims.ExpiresAt.Equal(testCase.wantClaims.ExpiresAt) {
+				t.Errorf("Verify() claims = %+v, want %+v", gotClaims, testCase.wantClaims)
+			}
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	privateKey, publicKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		parse   func(encoded string) error
+		encoded string
+		wantErr bool
+	}{
+		{
+			name:    "generated private key",
+			parse:   func(encoded string) error { _, err := ParsePrivateKey(encoded); return err },
+			encoded: privateKey,
+		},
+		{
+			name:    "generated public key",
+			parse:   func(encoded string) error { _, err := ParsePublicKey(encoded); return err },
+			encoded: publicKey,
+		},
+		{
+			name:    "private key is not base64",
+			parse:   func(encoded string) error { _, err := ParsePrivateKey(encoded); return err },
+			encoded: "not base64!",
+			wantErr: true,
+		},
+		{
+			name:    "private key of the wrong size",
+			parse:   func(encoded string) error { _, err := ParsePrivateKey(encoded); return err },
+			encoded: "c2hvcnQ=",
+			wantErr: true,
+		},
+		{
+			name:    "public key of the wrong size",
+			parse:   func(encoded string) error { _, err := ParsePublicKey(encoded); return err },
+			encoded: "c2hvcnQ=",
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.parse(testCase.encoded); (err != nil) != testCase.wantErr {
+				t.Errorf("parse(%q) error = %v, want error %t", testCase.encoded, err, testCase.wantErr)
+			}
+		})
+	}
+
+	parsedPrivateKey, _ := ParsePrivateKey(privateKey)
+	parsedPublicKey, _ := ParsePublicKey(publicKey)
+	if !parsedPublicKey.Equal(parsedPrivateKey.Public()) {
+		t.Errorf("generated public key does not match the generated private key")
+	}
+}